@@ -34,15 +34,24 @@ type App struct {
 	settingDefs       []settingDef
 	stateService      *services.AppStateService
 	expeditionService *services.ExpeditionService
+	hazardService     *services.HazardService
 	galaxyService     *services.GalaxyService
 	jobService        *services.JobService
 	availablePlotters map[string]plotters.Plotter
 
 	targetChan             chan *journal.FSDTargetEvent
+	lineErrorChan          chan *journal.LineError
+	navRouteChan           chan *journal.NavRouteFile
 	jumpHistoryChan        chan *models.JumpHistoryEntry
 	completeExpeditionChan chan *models.Expedition
 	currentJumpChan        chan *models.JumpHistoryEntry
 	fuelAlertChan          chan *services.FuelAlert
+	boostAlertChan         chan *services.BoostAlert
+	damageAlertChan        chan *services.DamageAlert
+	rejoinChan             chan *services.RejoinSuggestion
+	etaChan                chan *services.ETAPrediction
+	statusChan             chan *journal.Status
+	hazardAlertChan        chan *services.HazardAlert
 	jobStatusChan          chan *job.JobStatus
 }
 
@@ -162,6 +171,124 @@ func (a *App) getSettingDefs() []settingDef {
 				}
 			},
 		},
+		{
+			Config: form.InputFieldConfig{
+				Name:    "passenger_jumps",
+				Label:   "Passenger Jumps",
+				Type:    form.StringInput,
+				Section: "General",
+				Info:    "What to do with jumps made in a taxi or as multicrew. They never advance the expedition.",
+				Options: []form.InputOption{
+					{Value: string(models.PassengerJumpSkip), Label: "Ignore"},
+					{Value: string(models.PassengerJumpRecord), Label: "Record as off-expedition"},
+				},
+			},
+			Get: func() string {
+				return string(a.settings.PassengerJumpPolicy())
+			},
+			Apply: func(value string) error {
+				switch models.PassengerJumpPolicy(value) {
+				case models.PassengerJumpSkip, models.PassengerJumpRecord:
+					a.settings.PassengerJumps = models.PassengerJumpPolicy(value)
+					a.stateService.SetPassengerJumpPolicy(a.settings.PassengerJumps)
+					a.expeditionService.SetPassengerJumpPolicy(a.settings.PassengerJumps)
+					return models.SaveSettings(a.settings)
+				default:
+					return fmt.Errorf("invalid passenger jump policy: %s", value)
+				}
+			},
+		},
+		{
+			Config: form.InputFieldConfig{
+				Name:    "fsd_health_alert",
+				Label:   "FSD Health Alert (%)",
+				Type:    form.NumberInput,
+				Section: "General",
+				Info:    "Warn before a planned neutron jump when the FSD integrity is below this.",
+			},
+			Get: func() string {
+				return form.EncodeNumber(a.settings.FSDHealthThreshold() * 100)
+			},
+			Apply: func(value string) error {
+				threshold := int(form.ParseFloat(value))
+				if threshold < 1 || threshold > 100 {
+					return fmt.Errorf("FSD health alert must be between 1 and 100")
+				}
+				a.settings.FSDHealthAlert = threshold
+				a.expeditionService.SetFSDHealthThreshold(a.settings.FSDHealthThreshold())
+				return models.SaveSettings(a.settings)
+			},
+		},
+		{
+			Config: form.InputFieldConfig{
+				Name:    "rejoin_after",
+				Label:   "Rejoin After Off-Route Jumps",
+				Type:    form.NumberInput,
+				Section: "General",
+				Info:    "Plot a route back to the expedition after this many jumps off route, and copy it to the clipboard instead. 0 disables it. Needs the galaxy database.",
+			},
+			Get: func() string {
+				return form.EncodeNumber(float64(a.settings.RejoinAfter))
+			},
+			Apply: func(value string) error {
+				jumps := int(form.ParseFloat(value))
+				if jumps < 0 {
+					return fmt.Errorf("rejoin after can not be negative")
+				}
+				a.settings.RejoinAfter = jumps
+				a.expeditionService.SetRejoinAfter(jumps)
+				return models.SaveSettings(a.settings)
+			},
+		},
+		{
+			Config: form.InputFieldConfig{
+				Name:    "preflight",
+				Label:   "Pre-flight Check",
+				Type:    form.StringInput,
+				Section: "General",
+				Info:    "What to do when starting an expedition the current ship can not fly as planned, e.g. a jump only a neutron star reaches or running out of fuel.",
+				Options: []form.InputOption{
+					{Value: string(models.PreflightBlock), Label: "Refuse to start"},
+					{Value: string(models.PreflightWarn), Label: "Warn only"},
+				},
+			},
+			Get: func() string {
+				return string(a.settings.PreflightPolicy())
+			},
+			Apply: func(value string) error {
+				switch models.PreflightPolicy(value) {
+				case models.PreflightBlock, models.PreflightWarn:
+					a.settings.Preflight = models.PreflightPolicy(value)
+					a.expeditionService.SetPreflightPolicy(a.settings.Preflight)
+					return models.SaveSettings(a.settings)
+				default:
+					return fmt.Errorf("invalid pre-flight policy: %s", value)
+				}
+			},
+		},
+		{
+			Config: form.InputFieldConfig{
+				Name:    "journal_poll_interval",
+				Label:   "Journal Poll Interval (ms)",
+				Type:    form.NumberInput,
+				Section: "Advanced",
+				Info:    "How often to check the journal directory for changes when file system events don't arrive, e.g. on network mounts.",
+			},
+			Get: func() string {
+				return form.EncodeNumber(float64(a.journalPollInterval().Milliseconds()))
+			},
+			Apply: func(value string) error {
+				interval := int(form.ParseFloat(value))
+				if interval < 100 {
+					return fmt.Errorf("poll interval must be at least 100ms")
+				}
+				a.settings.JournalPollInterval = interval
+				if a.journalWatcher != nil {
+					a.journalWatcher.SetPollInterval(a.journalPollInterval())
+				}
+				return models.SaveSettings(a.settings)
+			},
+		},
 		{
 			Config: form.InputFieldConfig{
 				Name:    "debug",
@@ -182,6 +309,13 @@ func (a *App) getSettingDefs() []settingDef {
 	return a.settingDefs
 }
 
+func (a *App) journalPollInterval() time.Duration {
+	if a.settings.JournalPollInterval <= 0 {
+		return journal.DefaultPollInterval
+	}
+	return time.Duration(a.settings.JournalPollInterval) * time.Millisecond
+}
+
 func (a *App) GetSettingsConfig() []form.InputFieldConfig {
 	defs := a.getSettingDefs()
 	configs := make([]form.InputFieldConfig, len(defs))
@@ -210,7 +344,18 @@ func (a *App) initCoreServices() error {
 	}
 	a.expeditionService = services.NewExpeditionService(a.logger, lastKnownLocation)
 
+	a.stateService.SetPassengerJumpPolicy(a.settings.PassengerJumpPolicy())
+	a.expeditionService.SetPassengerJumpPolicy(a.settings.PassengerJumpPolicy())
+	a.expeditionService.SetFSDHealthThreshold(a.settings.FSDHealthThreshold())
+	a.expeditionService.SetRejoinAfter(a.settings.RejoinAfter)
+	a.expeditionService.SetRejoinPlotter(a.plotRejoinRoute)
+	a.expeditionService.SetPreflightPolicy(a.settings.PreflightPolicy())
+	a.expeditionService.SetFeasibilityChecker(a.checkFeasibility)
+
+	a.hazardService = services.NewHazardService(a.logger)
+
 	a.galaxyService = services.NewGalaxyService(a.logger)
+	a.expeditionService.SetSystemsAround(a.galaxyService.GetSystemsAround)
 	a.jobService = services.NewJobService(a.logger)
 
 	return nil
@@ -241,13 +386,20 @@ func (a *App) startupJournalServices() error {
 		return fmt.Errorf("failed to watch journal directory: %w", err)
 	}
 	a.journalWatcher = watcher
+	watcher.SetPollInterval(a.journalPollInterval())
 
 	a.stateService.SetWatcher(watcher)
 	a.stateService.Start()
 
 	a.expeditionService.SetWatcher(watcher)
+	if a.stateService.State.CurrentCommander != nil {
+		a.expeditionService.SetCommander(*a.stateService.State.CurrentCommander)
+	}
 	a.expeditionService.Start()
 
+	a.hazardService.SetWatcher(watcher)
+	a.hazardService.Start()
+
 	a.jumpHistoryChan = a.expeditionService.JumpHistory.Subscribe()
 	go func() {
 		for event := range a.jumpHistoryChan {
@@ -265,6 +417,20 @@ func (a *App) startupJournalServices() error {
 		}
 	}()
 
+	a.navRouteChan = watcher.NavRoute.Subscribe()
+	go func() {
+		for event := range a.navRouteChan {
+			runtime.EventsEmit(a.ctx, "NavRoute", *event)
+		}
+	}()
+
+	a.lineErrorChan = watcher.LineErrors.Subscribe()
+	go func() {
+		for event := range a.lineErrorChan {
+			runtime.EventsEmit(a.ctx, "JournalLineError", *event)
+		}
+	}()
+
 	a.completeExpeditionChan = a.expeditionService.CompleteExpedition.Subscribe()
 	go func() {
 		for event := range a.completeExpeditionChan {
@@ -286,7 +452,60 @@ func (a *App) startupJournalServices() error {
 		}
 	}()
 
-	if a.expeditionService.Index.ActiveExpeditionID != nil && a.stateService.State.JournalSync != nil {
+	a.boostAlertChan = a.expeditionService.BoostAlert.Subscribe()
+	go func() {
+		for event := range a.boostAlertChan {
+			runtime.EventsEmit(a.ctx, "BoostAlert", *event)
+		}
+	}()
+
+	a.damageAlertChan = a.expeditionService.DamageAlert.Subscribe()
+	go func() {
+		for event := range a.damageAlertChan {
+			runtime.EventsEmit(a.ctx, "DamageAlert", *event)
+		}
+	}()
+
+	a.etaChan = a.expeditionService.ETA.Subscribe()
+	go func() {
+		for event := range a.etaChan {
+			runtime.EventsEmit(a.ctx, "ETA", *event)
+		}
+	}()
+
+	a.rejoinChan = a.expeditionService.Rejoin.Subscribe()
+	go func() {
+		for event := range a.rejoinChan {
+			runtime.EventsEmit(a.ctx, "Rejoin", *event)
+			if event.NextSystem != "" {
+				runtime.ClipboardSetText(a.ctx, event.NextSystem)
+			}
+		}
+	}()
+
+	a.statusChan = watcher.Status.Subscribe()
+	go func() {
+		for event := range a.statusChan {
+			runtime.EventsEmit(a.ctx, "Status", *event)
+		}
+	}()
+
+	a.hazardAlertChan = a.hazardService.Alerts.Subscribe()
+	go func() {
+		for event := range a.hazardAlertChan {
+			runtime.EventsEmit(a.ctx, "HazardAlert", *event)
+		}
+	}()
+
+	// Indexed also without a sync, the next startup may need to skip the
+	// journals read live
+	journalIndex, err := models.LoadJournalIndex(a.journalDir)
+	if err != nil {
+		a.logger.Warning(fmt.Sprintf("[app.go] failed to load journal index, rebuilding it: %v", err))
+	}
+	watcher.SetIndex(journalIndex, models.SaveJournalIndex)
+
+	if a.expeditionService.Index.HasActive() && a.stateService.State.JournalSync != nil {
 		if err := watcher.Sync(*a.stateService.State.JournalSync); err != nil {
 			return fmt.Errorf("failed to sync journal: %w", err)
 		}
@@ -311,6 +530,14 @@ func (a *App) teardownJournalServices() {
 		a.journalWatcher.FSDTarget.Unsubscribe(a.targetChan)
 		a.targetChan = nil
 	}
+	if a.navRouteChan != nil {
+		a.journalWatcher.NavRoute.Unsubscribe(a.navRouteChan)
+		a.navRouteChan = nil
+	}
+	if a.lineErrorChan != nil {
+		a.journalWatcher.LineErrors.Unsubscribe(a.lineErrorChan)
+		a.lineErrorChan = nil
+	}
 	if a.completeExpeditionChan != nil {
 		a.expeditionService.CompleteExpedition.Unsubscribe(a.completeExpeditionChan)
 		a.completeExpeditionChan = nil
@@ -323,9 +550,34 @@ func (a *App) teardownJournalServices() {
 		a.expeditionService.FuelAlert.Unsubscribe(a.fuelAlertChan)
 		a.fuelAlertChan = nil
 	}
+	if a.boostAlertChan != nil {
+		a.expeditionService.BoostAlert.Unsubscribe(a.boostAlertChan)
+		a.boostAlertChan = nil
+	}
+	if a.damageAlertChan != nil {
+		a.expeditionService.DamageAlert.Unsubscribe(a.damageAlertChan)
+		a.damageAlertChan = nil
+	}
+	if a.etaChan != nil {
+		a.expeditionService.ETA.Unsubscribe(a.etaChan)
+		a.etaChan = nil
+	}
+	if a.rejoinChan != nil {
+		a.expeditionService.Rejoin.Unsubscribe(a.rejoinChan)
+		a.rejoinChan = nil
+	}
+	if a.statusChan != nil {
+		a.journalWatcher.Status.Unsubscribe(a.statusChan)
+		a.statusChan = nil
+	}
+	if a.hazardAlertChan != nil {
+		a.hazardService.Alerts.Unsubscribe(a.hazardAlertChan)
+		a.hazardAlertChan = nil
+	}
 
 	a.stateService.Stop()
 	a.expeditionService.Stop()
+	a.hazardService.Stop()
 
 	a.journalWatcher.Close()
 	a.journalWatcher = nil
@@ -334,6 +586,7 @@ func (a *App) teardownJournalServices() {
 func (a *App) initAvailablePlotters() {
 	a.availablePlotters = map[string]plotters.Plotter{
 		"spansh_galaxy_plotter": plotters.SpanshGalaxyPlotter{},
+		"ingame_navroute":       plotters.InGameNavRoutePlotter{Source: a.readNavRoute},
 	}
 
 	if a.galaxyService.State() == services.GalaxyStateReady {
@@ -341,6 +594,37 @@ func (a *App) initAvailablePlotters() {
 	}
 }
 
+// plotRejoinRoute plots a route back to the expedition with the basic plotter
+// and default inputs
+func (a *App) plotRejoinRoute(from, to string) (*models.Route, error) {
+	plotter, ok := a.availablePlotters["basic_plotter"]
+	if !ok {
+		return nil, fmt.Errorf("The galaxy database is needed to plot a route back")
+	}
+	loadout := a.stateService.State.LastKnownLoadout
+	if loadout == nil {
+		return nil, fmt.Errorf("No known loadout to plot with")
+	}
+	tracker := job.NewObservableProgressTracker(func(*job.ProgressTracker) {})
+	return plotter.Plot(from, to, form.InputValues{}, loadout, a.logger, tracker)
+}
+
+// checkFeasibility checks a route against the last known loadout
+func (a *App) checkFeasibility(route *models.Route) (*models.FeasibilityReport, error) {
+	loadout := a.stateService.State.LastKnownLoadout
+	if loadout == nil {
+		return nil, fmt.Errorf("No ship loadout available - please load game first")
+	}
+	return plotters.CheckFeasibility(route, loadout)
+}
+
+func (a *App) readNavRoute() (*journal.NavRouteFile, error) {
+	if a.journalDir == "" {
+		return nil, fmt.Errorf("No journal directory configured")
+	}
+	return journal.ReadNavRoute(a.journalDir)
+}
+
 func (a *App) shutdown(ctx context.Context) {
 	a.teardownJournalServices()
 
@@ -582,6 +866,47 @@ func (a *App) CloneExpedition(id string) (string, error) {
 	return a.expeditionService.CloneExpedition(id)
 }
 
+// ExportExpedition asks where to save the expedition bundle and writes it.
+// Returns the path written, empty if the dialog was cancelled.
+func (a *App) ExportExpedition(id string) (string, error) {
+	data, err := a.expeditionService.ExportExpedition(id)
+	if err != nil {
+		return "", err
+	}
+
+	path, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+		Title:           "Export expedition",
+		DefaultFilename: "expedition.json",
+		Filters:         []runtime.FileFilter{{DisplayName: "Expedition bundle (*.json)", Pattern: "*.json"}},
+	})
+	if err != nil || path == "" {
+		return "", err
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", fmt.Errorf("failed to write expedition bundle: %w", err)
+	}
+	return path, nil
+}
+
+// ImportExpedition asks for an expedition bundle and imports it as a planned
+// expedition. Returns the new expedition ID, empty if the dialog was cancelled.
+func (a *App) ImportExpedition() (string, error) {
+	path, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+		Title:   "Import expedition",
+		Filters: []runtime.FileFilter{{DisplayName: "Expedition bundle (*.json)", Pattern: "*.json"}},
+	})
+	if err != nil || path == "" {
+		return "", err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read expedition bundle: %w", err)
+	}
+	return a.expeditionService.ImportExpedition(data)
+}
+
 func (a *App) LoadExpedition(id string) (*models.Expedition, error) {
 	return models.LoadExpedition(id)
 }
@@ -622,19 +947,27 @@ func (a *App) GetLoadout() *models.Loadout {
 	return a.stateService.State.LastKnownLoadout
 }
 
+// GetShipLoadouts returns the last known loadout of every ship the current
+// commander owns
+func (a *App) GetShipLoadouts() []*models.Loadout {
+	return a.stateService.State.ShipLoadouts()
+}
+
 type plotRouteCtx struct {
 	Route *models.Route
 }
 
-func (a *App) PlotRoute(expeditionId, plotterId, from, to string, inputs form.InputValues) (string, error) {
+// PlotRoute plots a route for the ship with the given ID, or the current ship
+// if shipId is nil
+func (a *App) PlotRoute(expeditionId, plotterId, from, to string, inputs form.InputValues, shipId *int) (string, error) {
 	plotter, ok := a.availablePlotters[plotterId]
 	if !ok {
 		return "", fmt.Errorf("Unknown plotter id '%s'", plotterId)
 	}
 
-	loadout := a.stateService.State.LastKnownLoadout
-	if loadout == nil {
-		return "", fmt.Errorf("No ship loadout available - please load game first")
+	loadout, err := a.plotLoadout(shipId)
+	if err != nil {
+		return "", err
 	}
 
 	if a.galaxyService.State() == services.GalaxyStateReady {
@@ -684,6 +1017,76 @@ func (a *App) PlotRoute(expeditionId, plotterId, from, to string, inputs form.In
 	return j.Id(), nil
 }
 
+func (a *App) plotLoadout(shipId *int) (*models.Loadout, error) {
+	loadout := a.stateService.State.LastKnownLoadout
+	if shipId != nil {
+		loadout = a.stateService.State.ShipLoadout(*shipId)
+		if loadout == nil {
+			return nil, fmt.Errorf("No loadout known for ship %d - please board it once first", *shipId)
+		}
+	}
+	if loadout == nil {
+		return nil, fmt.Errorf("No ship loadout available - please load game first")
+	}
+	return loadout, nil
+}
+
+// RewindExpedition plots a route from where the commander respawned after
+// dying back to the last system they visited, and splices it into the active
+// expedition.
+func (a *App) RewindExpedition(plotterId string, inputs form.InputValues, shipId *int) (string, error) {
+	plotter, ok := a.availablePlotters[plotterId]
+	if !ok {
+		return "", fmt.Errorf("Unknown plotter id '%s'", plotterId)
+	}
+
+	loadout, err := a.plotLoadout(shipId)
+	if err != nil {
+		return "", err
+	}
+
+	from, to, err := a.expeditionService.RewindTarget()
+	if err != nil {
+		return "", err
+	}
+
+	j := job.New("Rewind Expedition", plotRouteCtx{}, []job.PhaseConfig[plotRouteCtx]{
+		{
+			Name:  "plot",
+			Label: fmt.Sprintf("%s → %s", from.SystemName, to.SystemName),
+			Type:  plotter.ProgressType(),
+			Callback: func(ctx context.Context, state *plotRouteCtx, tracker *job.ProgressTracker) error {
+				route, err := plotter.Plot(from.SystemName, to.SystemName, inputs, loadout, a.logger, tracker)
+				if err != nil {
+					return err
+				}
+				state.Route = route
+				return nil
+			},
+		},
+	}, func(state plotRouteCtx) (*models.Route, error) {
+		if err := a.expeditionService.SpliceRewindRoute(state.Route); err != nil {
+			return nil, fmt.Errorf("failed to splice rewind route: %w", err)
+		}
+		return state.Route, nil
+	}, a.logger)
+
+	a.jobService.RegisterAndRun(j, a.ctx)
+	return j.Id(), nil
+}
+
+// ImportNavRoute adds the route currently plotted in game to the expedition
+func (a *App) ImportNavRoute(expeditionId string) (*models.Route, error) {
+	route, err := a.availablePlotters["ingame_navroute"].Plot("", "", form.InputValues{}, a.stateService.State.LastKnownLoadout, a.logger, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.expeditionService.AddRouteToExpedition(expeditionId, route); err != nil {
+		return nil, fmt.Errorf("failed to add route to expedition: %w", err)
+	}
+	return route, nil
+}
+
 func (a *App) DeleteExpedition(id string) error {
 	return a.expeditionService.DeleteExpedition(id)
 }
@@ -692,6 +1095,14 @@ func (a *App) RenameExpedition(id, name string) error {
 	return a.expeditionService.RenameExpedition(id, name)
 }
 
+func (a *App) SetExpeditionCountCarrierJumps(id string, count bool) error {
+	return a.expeditionService.SetCountCarrierJumps(id, count)
+}
+
+func (a *App) SetExpeditionMaxLaps(id string, laps int) error {
+	return a.expeditionService.SetMaxLaps(id, laps)
+}
+
 func (a *App) RenameRoute(routeId, name string) error {
 	route, err := models.LoadRoute(routeId)
 	if err != nil {
@@ -712,6 +1123,10 @@ func (a *App) CreateLink(expeditionId string, from, to models.RoutePosition) err
 	return a.expeditionService.CreateLink(expeditionId, from, to)
 }
 
+func (a *App) SetLinkAlternative(expeditionId, linkId string, alternative bool) error {
+	return a.expeditionService.SetLinkAlternative(expeditionId, linkId, alternative)
+}
+
 func (a *App) DeleteLink(expeditionId, linkId string) error {
 	return a.expeditionService.DeleteLink(expeditionId, linkId)
 }
@@ -721,7 +1136,75 @@ func (a *App) StartExpedition(expeditionId string) error {
 	if a.stateService.State.LastKnownLocation != nil {
 		currentSystemId = &a.stateService.State.LastKnownLocation.SystemID
 	}
-	return a.expeditionService.StartExpedition(expeditionId, currentSystemId)
+	var commanderFID string
+	if a.stateService.State.CurrentCommander != nil {
+		commanderFID = *a.stateService.State.CurrentCommander
+	}
+	return a.expeditionService.StartExpedition(expeditionId, currentSystemId, commanderFID)
+}
+
+// PreflightCheck reports whether the current ship can fly the expedition as
+// planned, before it is started
+func (a *App) PreflightCheck(expeditionId string) (*models.FeasibilityReport, error) {
+	return a.expeditionService.PreflightCheck(expeditionId)
+}
+
+// RebuildExpeditionHistory reconstructs the jump history from the journals and
+// returns it along with a diff, without writing anything.
+func (a *App) RebuildExpeditionHistory(expeditionId string) (*services.HistoryRebuild, error) {
+	return a.expeditionService.RebuildHistory(expeditionId)
+}
+
+func (a *App) ApplyExpeditionHistoryRebuild(expeditionId string) error {
+	return a.expeditionService.ApplyHistoryRebuild(expeditionId)
+}
+
+// GetExpeditionStats returns the stats of an expedition, recompute refreshes
+// the stats cached when it finished
+func (a *App) GetExpeditionStats(expeditionId string, recompute bool) (*models.ExpeditionStats, error) {
+	return a.expeditionService.ExpeditionStats(expeditionId, recompute)
+}
+
+// GetExpeditionETA returns the prediction for the active expedition, null
+// without one
+func (a *App) GetExpeditionETA() *services.ETAPrediction {
+	return a.expeditionService.PredictETA()
+}
+
+func (a *App) PauseExpedition() error {
+	return a.expeditionService.PauseExpedition()
+}
+
+func (a *App) EndPausedExpedition(expeditionId string) error {
+	return a.expeditionService.EndPausedExpedition(expeditionId)
+}
+
+func (a *App) ResumeExpedition(expeditionId string) error {
+	var currentSystemId *int64
+	if a.stateService.State.LastKnownLocation != nil {
+		currentSystemId = &a.stateService.State.LastKnownLocation.SystemID
+	}
+	var commanderFID string
+	if a.stateService.State.CurrentCommander != nil {
+		commanderFID = *a.stateService.State.CurrentCommander
+	}
+	return a.expeditionService.ResumeExpedition(expeditionId, currentSystemId, commanderFID)
+}
+
+func (a *App) SetCurrentBakedIndex(expeditionId string, index int, note string) error {
+	return a.expeditionService.SetCurrentBakedIndex(expeditionId, index, note)
+}
+
+func (a *App) InsertSkippedJumps(expeditionId string, index int, note string) error {
+	return a.expeditionService.InsertSkippedJumps(expeditionId, index, note)
+}
+
+func (a *App) DeleteHistoryEntry(expeditionId string, index int, note string) error {
+	return a.expeditionService.DeleteHistoryEntry(expeditionId, index, note)
+}
+
+func (a *App) MarkHistoryEntry(expeditionId string, index int, erroneous bool, note string) error {
+	return a.expeditionService.MarkHistoryEntry(expeditionId, index, erroneous, note)
 }
 
 func (a *App) EndActiveExpedition() error {
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"ed-expedition/journal"
 	"flag"
 	"fmt"
@@ -27,10 +28,26 @@ const fixedTimestamp = "2025-01-01T00:00:00Z"
 func main() {
 	// Command-line flags
 	normalizeTime := flag.Bool("normalize-time", false, "Use fixed timestamp for output (for comparison)")
+	journalDirFlag := flag.String("journal-dir", "./data/journals", "Journal directory to watch")
+	inputFile := flag.String("input", "", "Path to a JSON array file to replay into the journal directory")
+	inputDir := flag.String("input-dir", "", "Directory of journals to replay into the journal directory")
+	delay := flag.Int("delay", 100, "Delay between replayed writes in milliseconds")
 	flag.Parse()
 
-	// Create watcher for ./data/journals
-	journalDir := "./data/journals"
+	journalDir := *journalDirFlag
+
+	var events []journal.ReplayEvent
+	var err error
+	switch {
+	case *inputFile != "":
+		events, err = journal.LoadReplayEventsFromJson(*inputFile)
+	case *inputDir != "":
+		events, err = journal.LoadReplayEventsFromDir(*inputDir)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading events: %v\n", err)
+		os.Exit(1)
+	}
 
 	watcher, err := journal.NewWatcher(journalDir, &TestLogger{})
 	if err != nil {
@@ -81,10 +98,26 @@ func main() {
 	fmt.Println("Waiting for events... (Ctrl+C to exit)")
 	watcher.Start()
 
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	// Replay the input through the watched directory, like the game would
+	if len(events) > 0 {
+		go func() {
+			replayer := journal.NewReplayer(journalDir)
+			replayer.Delay = time.Duration(*delay) * time.Millisecond
+			replayer.RewriteTimestamps = true
+			defer replayer.Close()
+
+			fmt.Printf("Replaying %d events\n", len(events))
+			if err := replayer.Replay(ctx, events); err != nil && ctx.Err() == nil {
+				fmt.Fprintf(os.Stderr, "Error replaying events: %v\n", err)
+			}
+		}()
+	}
+
 	// Wait for interrupt signal
-	sigCh := make(chan os.Signal, 1)
-	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
-	<-sigCh
+	<-ctx.Done()
 
 	fmt.Println("\nShutting down...")
 }
@@ -2,12 +2,11 @@ package main
 
 import (
 	"bufio"
+	"ed-expedition/journal"
 	"ed-expedition/models"
-	"encoding/json"
 	"fmt"
 	"math/rand"
 	"os"
-	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -17,8 +16,7 @@ type REPL struct {
 	expedition        *models.Expedition
 	bakedRoute        *models.Route
 	journalDir        string
-	journalFile       *os.File
-	journalPath       string
+	replayer          *journal.Replayer
 	scanner           *bufio.Scanner
 	lastCommand       string
 	lastFuelLevel     float64
@@ -47,15 +45,7 @@ func NewREPL(journalDir string) (*REPL, error) {
 		return nil, fmt.Errorf("failed to load baked route: %w", err)
 	}
 
-	// Create/open journal file
-	now := time.Now()
-	filename := fmt.Sprintf("Journal.%s.01.log", now.Format("2006-01-02T150405"))
-	journalPath := filepath.Join(journalDir, filename)
-
-	journalFile, err := os.OpenFile(journalPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open journal file: %w", err)
-	}
+	replayer := journal.NewReplayer(journalDir)
 
 	// Initialize fuel level from current position in route
 	lastFuelLevel := 16.0 // Default tank capacity
@@ -76,8 +66,7 @@ func NewREPL(journalDir string) (*REPL, error) {
 		expedition:        expedition,
 		bakedRoute:        bakedRoute,
 		journalDir:        journalDir,
-		journalFile:       journalFile,
-		journalPath:       journalPath,
+		replayer:          replayer,
 		scanner:           bufio.NewScanner(os.Stdin),
 		lastFuelLevel:     lastFuelLevel,
 		lastJumpScoopable: lastJumpScoopable,
@@ -85,17 +74,12 @@ func NewREPL(journalDir string) (*REPL, error) {
 }
 
 func (r *REPL) writeStatus() error {
-	const (
-		flagScoopingFuel = 1 << 11
-		flagInMainShip   = 1 << 24
-	)
-
-	flags := flagInMainShip
+	flags := journal.FlagInMainShip
 	if r.scooping {
-		flags |= flagScoopingFuel
+		flags |= journal.FlagScoopingFuel
 	}
 
-	status := map[string]interface{}{
+	status := journal.ReplayEvent{
 		"timestamp": time.Now().UTC().Format(time.RFC3339),
 		"event":     "Status",
 		"Flags":     flags,
@@ -105,13 +89,7 @@ func (r *REPL) writeStatus() error {
 		},
 	}
 
-	content, err := json.Marshal(status)
-	if err != nil {
-		return fmt.Errorf("failed to marshal status: %w", err)
-	}
-
-	statusPath := filepath.Join(r.journalDir, "Status.json")
-	if err := os.WriteFile(statusPath, content, 0644); err != nil {
+	if err := r.replayer.Write(status); err != nil {
 		return fmt.Errorf("failed to write status: %w", err)
 	}
 
@@ -120,15 +98,13 @@ func (r *REPL) writeStatus() error {
 }
 
 func (r *REPL) Close() {
-	if r.journalFile != nil {
-		r.journalFile.Close()
-	}
+	r.replayer.Close()
 }
 
 func (r *REPL) writeJump(systemName string, systemID int64, distance, fuelUsed, fuelLevel float64) error {
 	r.lastFuelLevel = fuelLevel
 
-	event := map[string]interface{}{
+	event := journal.ReplayEvent{
 		"timestamp":                     time.Now().UTC().Format(time.RFC3339),
 		"event":                         "FSDJump",
 		"Taxi":                          false,
@@ -154,19 +130,10 @@ func (r *REPL) writeJump(systemName string, systemID int64, distance, fuelUsed,
 		"FuelLevel":                     fuelLevel,
 	}
 
-	line, err := json.Marshal(event)
-	if err != nil {
-		return fmt.Errorf("failed to marshal event: %w", err)
-	}
-
-	if _, err := fmt.Fprintf(r.journalFile, "%s\n", line); err != nil {
+	if err := r.replayer.Write(event); err != nil {
 		return fmt.Errorf("failed to write event: %w", err)
 	}
 
-	if err := r.journalFile.Sync(); err != nil {
-		return fmt.Errorf("failed to sync file: %w", err)
-	}
-
 	// Give the watcher time to process the event
 	time.Sleep(50 * time.Millisecond)
 
@@ -179,7 +146,7 @@ func (r *REPL) writeJump(systemName string, systemID int64, distance, fuelUsed,
 }
 
 func (r *REPL) writeTarget(systemName string, systemID int64, starClass string) error {
-	event := map[string]interface{}{
+	event := journal.ReplayEvent{
 		"timestamp":     time.Now().UTC().Format(time.RFC3339),
 		"event":         "FSDTarget",
 		"Name":          systemName,
@@ -187,19 +154,10 @@ func (r *REPL) writeTarget(systemName string, systemID int64, starClass string)
 		"StarClass":     starClass,
 	}
 
-	line, err := json.Marshal(event)
-	if err != nil {
-		return fmt.Errorf("failed to marshal event: %w", err)
-	}
-
-	if _, err := fmt.Fprintf(r.journalFile, "%s\n", line); err != nil {
+	if err := r.replayer.Write(event); err != nil {
 		return fmt.Errorf("failed to write event: %w", err)
 	}
 
-	if err := r.journalFile.Sync(); err != nil {
-		return fmt.Errorf("failed to sync file: %w", err)
-	}
-
 	// Give the watcher time to process the event
 	time.Sleep(50 * time.Millisecond)
 
@@ -465,13 +423,13 @@ func (r *REPL) printStatus() {
 	} else {
 		fmt.Printf("Next System: None (at end of route)\n")
 	}
-	fmt.Printf("Journal: %s\n", r.journalPath)
+	fmt.Printf("Journal: %s\n", r.replayer.CurrentFile())
 	fmt.Println()
 }
 
 func (r *REPL) Run() {
 	fmt.Println("=== Elite Dangerous Jump REPL ===")
-	fmt.Printf("Writing to: %s\n", r.journalPath)
+	fmt.Printf("Writing to: %s\n", r.journalDir)
 	r.printStatus()
 	fmt.Println("Type 'help' for available commands")
 	fmt.Println()
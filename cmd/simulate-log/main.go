@@ -1,62 +1,49 @@
 package main
 
 import (
-	"encoding/json"
+	"context"
+	"ed-expedition/journal"
+	"ed-expedition/models"
 	"flag"
 	"fmt"
 	"os"
-	"path/filepath"
-	"regexp"
+	"os/signal"
 	"time"
 )
 
-// generateFilename takes a filename and part number, returns filename with updated part number
-func generateFilename(baseFilename string, partNum int) string {
-	// Pattern: Journal.2025-12-06T173759.01.log
-	pattern := regexp.MustCompile(`^(Journal\.\d{4}-\d{2}-\d{2}T\d{6}\.)(\d+)(\.log)$`)
-
-	if matches := pattern.FindStringSubmatch(baseFilename); matches != nil {
-		// Use the base filename pattern with new part number
-		return fmt.Sprintf("%s%02d%s", matches[1], partNum, matches[3])
-	}
-
-	// Fallback: append part number before extension
-	ext := filepath.Ext(baseFilename)
-	base := baseFilename[:len(baseFilename)-len(ext)]
-	return fmt.Sprintf("%s.%02d%s", base, partNum, ext)
-}
-
 func main() {
-	// Generate default filename with current timestamp
-	now := time.Now()
-	defaultFilename := fmt.Sprintf("Journal.%s.01.log", now.Format("2006-01-02T150405"))
-
 	// Command-line flags
 	inputFile := flag.String("input", "", "Path to JSON array file")
-	outputDir := flag.String("output-dir", ".", "Directory to write log file to")
-	outputFile := flag.String("output-file", defaultFilename, "Name of output log file")
-	delay := flag.Int("delay", 100, "Delay between writes in milliseconds")
+	inputDir := flag.String("input-dir", "", "Directory of journals to replay")
+	routeId := flag.String("route", "", "ID of a route to generate a flight for (uses ED_EXPEDITION_DATA_DIR)")
+	outputDir := flag.String("output-dir", ".", "Directory to write journals and Status.json to")
+	outputFile := flag.String("output-file", "", "Name of the first output log file (defaults to one named after the first event)")
+	delay := flag.Int("delay", 100, "Delay between writes in milliseconds, used when -speed is 0")
+	speed := flag.Float64("speed", 0, "Replay the original timing, sped up by this factor (1 = real time)")
+	maxDelay := flag.Int("max-delay", 0, "Cap on a single delay in milliseconds, 0 for no cap")
 	count := flag.Int("count", 1, "Number of files to split output into (increments part number)")
 	flag.Parse()
 
-	// Validate required flags
-	if *inputFile == "" {
-		fmt.Fprintln(os.Stderr, "Error: -input flag is required")
+	var events []journal.ReplayEvent
+	var err error
+	switch {
+	case *inputFile != "":
+		events, err = journal.LoadReplayEventsFromJson(*inputFile)
+	case *inputDir != "":
+		events, err = journal.LoadReplayEventsFromDir(*inputDir)
+	case *routeId != "":
+		var route *models.Route
+		route, err = models.LoadRoute(*routeId)
+		if err == nil {
+			events, err = journal.GenerateRouteEvents(route, journal.RouteEventOptions{})
+		}
+	default:
+		fmt.Fprintln(os.Stderr, "Error: one of -input, -input-dir or -route is required")
 		flag.Usage()
 		os.Exit(1)
 	}
-
-	// Read input JSON array
-	data, err := os.ReadFile(*inputFile)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error reading input file: %v\n", err)
-		os.Exit(1)
-	}
-
-	// Parse JSON array
-	var events []map[string]any
-	if err := json.Unmarshal(data, &events); err != nil {
-		fmt.Fprintf(os.Stderr, "Error parsing JSON: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Error loading events: %v\n", err)
 		os.Exit(1)
 	}
 
@@ -72,74 +59,31 @@ func main() {
 		os.Exit(1)
 	}
 
-	fmt.Printf("Total events: %d\n", len(events))
-	fmt.Printf("Delay between writes: %dms\n", *delay)
-	fmt.Printf("Splitting into %d file(s)\n\n", *count)
-
-	// Calculate events per file
-	eventsPerFile := len(events) / *count
-	remainder := len(events) % *count
-
-	delayDuration := time.Duration(*delay) * time.Millisecond
-	eventIndex := 0
-
-	for fileNum := 0; fileNum < *count; fileNum++ {
-		// Calculate how many events go in this file
-		numEvents := eventsPerFile
-		if fileNum < remainder {
-			numEvents++ // Distribute remainder across first files
-		}
-
-		// Generate filename with incremented part number
-		filename := generateFilename(*outputFile, fileNum+1)
-		outPath := filepath.Join(*outputDir, filename)
-
-		// Open output file
-		out, err := os.Create(outPath)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error creating output file: %v\n", err)
-			os.Exit(1)
-		}
-
-		fmt.Printf("Writing to: %s (%d events)\n", filename, numEvents)
-
-		// Write events for this file
-		for i := 0; i < numEvents; i++ {
-			event := events[eventIndex]
-			event["timestamp"] = time.Now().Format(time.RFC3339)
-
-			// Marshal event to JSON
-			line, err := json.Marshal(event)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error marshaling event %d: %v\n", eventIndex, err)
-				eventIndex++
-				continue
-			}
-
-			// Write line
-			if _, err := fmt.Fprintf(out, "%s\n", line); err != nil {
-				fmt.Fprintf(os.Stderr, "Error writing event %d: %v\n", eventIndex, err)
-				eventIndex++
-				continue
-			}
-
-			// Flush to ensure write is visible immediately
-			if err := out.Sync(); err != nil {
-				fmt.Fprintf(os.Stderr, "Error syncing event %d: %v\n", eventIndex, err)
-			}
-
-			fmt.Printf("  [%d/%d] %s\n", eventIndex+1, len(events), event["event"])
+	replayer := journal.NewReplayer(*outputDir)
+	replayer.Delay = time.Duration(*delay) * time.Millisecond
+	replayer.Speed = *speed
+	replayer.MaxDelay = time.Duration(*maxDelay) * time.Millisecond
+	replayer.RewriteTimestamps = true
+	replayer.FileName = *outputFile
+	if *count > 1 {
+		replayer.PartSize = (len(events) + *count - 1) / *count
+	}
+	defer replayer.Close()
 
-			eventIndex++
+	fmt.Printf("Total events: %d\n", len(events))
+	if *speed > 0 {
+		fmt.Printf("Replay speed: %gx\n", *speed)
+	} else {
+		fmt.Printf("Delay between writes: %dms\n", *delay)
+	}
+	fmt.Println()
 
-			// Delay before next write (except after last event)
-			if eventIndex < len(events) {
-				time.Sleep(delayDuration)
-			}
-		}
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
 
-		out.Close()
-		fmt.Println()
+	if err := replayer.Replay(ctx, events); err != nil {
+		fmt.Fprintf(os.Stderr, "Error replaying events: %v\n", err)
+		os.Exit(1)
 	}
 
 	fmt.Println("Simulation complete!")
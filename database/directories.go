@@ -15,13 +15,14 @@ const (
 )
 
 var (
-	DataDir        string
-	CacheDir       string
-	ConfigDir      string
-	AppStatePath   string
-	IndexPath      string
-	BuildStatePath string
-	SettingsPath   string
+	DataDir          string
+	CacheDir         string
+	ConfigDir        string
+	AppStatePath     string
+	IndexPath        string
+	BuildStatePath   string
+	SettingsPath     string
+	JournalIndexPath string
 )
 
 func init() {
@@ -54,6 +55,7 @@ func InitDirectories() error {
 	IndexPath = filepath.Join(DataDir, "index.json")
 	BuildStatePath = filepath.Join(CacheDir, "build.state.json")
 	SettingsPath = filepath.Join(ConfigDir, "settings.json")
+	JournalIndexPath = filepath.Join(CacheDir, "journal-index.json")
 
 	return nil
 }
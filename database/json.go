@@ -116,33 +116,48 @@ func ReadAndMigrateJSON[T any](path string, migrationRegistry migrations.Registr
 		return nil, err
 	}
 
+	result, migrated, err := UnmarshalAndMigrateJSON[T](data, migrationRegistry)
+	if err != nil {
+		return nil, err
+	}
+	if migrated != nil {
+		go WriteJSON(path, migrated)
+	}
+
+	return result, nil
+}
+
+// UnmarshalAndMigrateJSON is ReadAndMigrateJSON for data that is not read from
+// a file. If the data was migrated it also returns the migrated data as a map,
+// with any fields T does not know about, for writing back.
+func UnmarshalAndMigrateJSON[T any](data []byte, migrationRegistry migrations.Registry) (*T, map[string]any, error) {
 	var dataMap map[string]any
 	var result T
 
-	err = json.Unmarshal(data, &dataMap)
+	err := json.Unmarshal(data, &dataMap)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	migrated, err := migrations.Migrate(dataMap, migrationRegistry)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if migrated {
-		go WriteJSON(path, dataMap)
-
 		data, err = json.Marshal(dataMap)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
+	} else {
+		dataMap = nil
 	}
 
 	err = json.Unmarshal(data, &result)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return &result, nil
+	return &result, dataMap, nil
 }
 
 func WriteJSON(path string, data any) error {
@@ -7,6 +7,8 @@ import {form} from '../models';
 
 export function AcceptGalaxy():Promise<string>;
 
+export function ApplyExpeditionHistoryRebuild(arg1:string):Promise<void>;
+
 export function AutocompleteSystems(arg1:string):Promise<Array<string>>;
 
 export function BrowseDirectory(arg1:string):Promise<string>;
@@ -25,10 +27,20 @@ export function DeclineGalaxy():Promise<void>;
 
 export function DeleteExpedition(arg1:string):Promise<void>;
 
+export function DeleteHistoryEntry(arg1:string,arg2:number,arg3:string):Promise<void>;
+
 export function DeleteLink(arg1:string,arg2:string):Promise<void>;
 
 export function EndActiveExpedition():Promise<void>;
 
+export function EndPausedExpedition(arg1:string):Promise<void>;
+
+export function ExportExpedition(arg1:string):Promise<string>;
+
+export function GetExpeditionETA():Promise<services.ETAPrediction>;
+
+export function GetExpeditionStats(arg1:string,arg2:boolean):Promise<models.ExpeditionStats>;
+
 export function GetExpeditionSummaries():Promise<Array<models.ExpeditionSummary>>;
 
 export function GetGalaxyState():Promise<main.GalaxyStatus>;
@@ -43,6 +55,14 @@ export function GetPlotterOptions():Promise<Record<string, string>>;
 
 export function GetSettingsConfig():Promise<Array<form.InputFieldConfig>>;
 
+export function GetShipLoadouts():Promise<Array<models.Loadout>>;
+
+export function ImportExpedition():Promise<string>;
+
+export function ImportNavRoute(arg1:string):Promise<models.Route>;
+
+export function InsertSkippedJumps(arg1:string,arg2:number,arg3:string):Promise<void>;
+
 export function LoadActiveExpedition():Promise<main.LoadActiveExpeditionPayload>;
 
 export function LoadExpedition(arg1:string):Promise<models.Expedition>;
@@ -51,9 +71,17 @@ export function LoadRoutes(arg1:string):Promise<Array<models.Route>>;
 
 export function LookupSystem(arg1:string):Promise<main.SystemLookupResult>;
 
+export function MarkHistoryEntry(arg1:string,arg2:number,arg3:boolean,arg4:string):Promise<void>;
+
 export function MockJob(arg1:number):Promise<string>;
 
-export function PlotRoute(arg1:string,arg2:string,arg3:string,arg4:string,arg5:form.InputValues):Promise<string>;
+export function PauseExpedition():Promise<void>;
+
+export function PlotRoute(arg1:string,arg2:string,arg3:string,arg4:string,arg5:form.InputValues,arg6:any):Promise<string>;
+
+export function PreflightCheck(arg1:string):Promise<models.FeasibilityReport>;
+
+export function RebuildExpeditionHistory(arg1:string):Promise<services.HistoryRebuild>;
 
 export function RemoveRouteFromExpedition(arg1:string,arg2:string):Promise<void>;
 
@@ -61,8 +89,20 @@ export function RenameExpedition(arg1:string,arg2:string):Promise<void>;
 
 export function RenameRoute(arg1:string,arg2:string):Promise<void>;
 
+export function ResumeExpedition(arg1:string):Promise<void>;
+
+export function RewindExpedition(arg1:string,arg2:form.InputValues,arg3:any):Promise<string>;
+
+export function SetCurrentBakedIndex(arg1:string,arg2:number,arg3:string):Promise<void>;
+
+export function SetExpeditionCountCarrierJumps(arg1:string,arg2:boolean):Promise<void>;
+
+export function SetExpeditionMaxLaps(arg1:string,arg2:number):Promise<void>;
+
 export function SetJournalDir(arg1:string):Promise<void>;
 
+export function SetLinkAlternative(arg1:string,arg2:string,arg3:boolean):Promise<void>;
+
 export function StartExpedition(arg1:string):Promise<void>;
 
 export function UpdateSetting(arg1:string,arg2:string):Promise<void>;
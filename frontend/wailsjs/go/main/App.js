@@ -6,6 +6,10 @@ export function AcceptGalaxy() {
   return window['go']['main']['App']['AcceptGalaxy']();
 }
 
+export function ApplyExpeditionHistoryRebuild(arg1) {
+  return window['go']['main']['App']['ApplyExpeditionHistoryRebuild'](arg1);
+}
+
 export function AutocompleteSystems(arg1) {
   return window['go']['main']['App']['AutocompleteSystems'](arg1);
 }
@@ -42,6 +46,10 @@ export function DeleteExpedition(arg1) {
   return window['go']['main']['App']['DeleteExpedition'](arg1);
 }
 
+export function DeleteHistoryEntry(arg1, arg2, arg3) {
+  return window['go']['main']['App']['DeleteHistoryEntry'](arg1, arg2, arg3);
+}
+
 export function DeleteLink(arg1, arg2) {
   return window['go']['main']['App']['DeleteLink'](arg1, arg2);
 }
@@ -50,6 +58,22 @@ export function EndActiveExpedition() {
   return window['go']['main']['App']['EndActiveExpedition']();
 }
 
+export function EndPausedExpedition(arg1) {
+  return window['go']['main']['App']['EndPausedExpedition'](arg1);
+}
+
+export function ExportExpedition(arg1) {
+  return window['go']['main']['App']['ExportExpedition'](arg1);
+}
+
+export function GetExpeditionETA() {
+  return window['go']['main']['App']['GetExpeditionETA']();
+}
+
+export function GetExpeditionStats(arg1, arg2) {
+  return window['go']['main']['App']['GetExpeditionStats'](arg1, arg2);
+}
+
 export function GetExpeditionSummaries() {
   return window['go']['main']['App']['GetExpeditionSummaries']();
 }
@@ -78,6 +102,22 @@ export function GetSettingsConfig() {
   return window['go']['main']['App']['GetSettingsConfig']();
 }
 
+export function GetShipLoadouts() {
+  return window['go']['main']['App']['GetShipLoadouts']();
+}
+
+export function ImportExpedition() {
+  return window['go']['main']['App']['ImportExpedition']();
+}
+
+export function ImportNavRoute(arg1) {
+  return window['go']['main']['App']['ImportNavRoute'](arg1);
+}
+
+export function InsertSkippedJumps(arg1, arg2, arg3) {
+  return window['go']['main']['App']['InsertSkippedJumps'](arg1, arg2, arg3);
+}
+
 export function LoadActiveExpedition() {
   return window['go']['main']['App']['LoadActiveExpedition']();
 }
@@ -94,12 +134,28 @@ export function LookupSystem(arg1) {
   return window['go']['main']['App']['LookupSystem'](arg1);
 }
 
+export function MarkHistoryEntry(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['MarkHistoryEntry'](arg1, arg2, arg3, arg4);
+}
+
 export function MockJob(arg1) {
   return window['go']['main']['App']['MockJob'](arg1);
 }
 
-export function PlotRoute(arg1, arg2, arg3, arg4, arg5) {
-  return window['go']['main']['App']['PlotRoute'](arg1, arg2, arg3, arg4, arg5);
+export function PauseExpedition() {
+  return window['go']['main']['App']['PauseExpedition']();
+}
+
+export function PlotRoute(arg1, arg2, arg3, arg4, arg5, arg6) {
+  return window['go']['main']['App']['PlotRoute'](arg1, arg2, arg3, arg4, arg5, arg6);
+}
+
+export function PreflightCheck(arg1) {
+  return window['go']['main']['App']['PreflightCheck'](arg1);
+}
+
+export function RebuildExpeditionHistory(arg1) {
+  return window['go']['main']['App']['RebuildExpeditionHistory'](arg1);
 }
 
 export function RemoveRouteFromExpedition(arg1, arg2) {
@@ -114,10 +170,34 @@ export function RenameRoute(arg1, arg2) {
   return window['go']['main']['App']['RenameRoute'](arg1, arg2);
 }
 
+export function ResumeExpedition(arg1) {
+  return window['go']['main']['App']['ResumeExpedition'](arg1);
+}
+
+export function RewindExpedition(arg1, arg2, arg3) {
+  return window['go']['main']['App']['RewindExpedition'](arg1, arg2, arg3);
+}
+
+export function SetCurrentBakedIndex(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SetCurrentBakedIndex'](arg1, arg2, arg3);
+}
+
+export function SetExpeditionCountCarrierJumps(arg1, arg2) {
+  return window['go']['main']['App']['SetExpeditionCountCarrierJumps'](arg1, arg2);
+}
+
+export function SetExpeditionMaxLaps(arg1, arg2) {
+  return window['go']['main']['App']['SetExpeditionMaxLaps'](arg1, arg2);
+}
+
 export function SetJournalDir(arg1) {
   return window['go']['main']['App']['SetJournalDir'](arg1);
 }
 
+export function SetLinkAlternative(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SetLinkAlternative'](arg1, arg2, arg3);
+}
+
 export function StartExpedition(arg1) {
   return window['go']['main']['App']['StartExpedition'](arg1);
 }
@@ -170,6 +170,225 @@ export namespace models {
 	    INJECTION_STANDARD = 0x3,
 	    NEUTRON = 0x1,
 	    NONE = 0x0,
+	    WHITE_DWARF = 0x5,
+	}
+	export class BodySignal {
+	    type: string;
+	    count: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new BodySignal(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.type = source["type"];
+	        this.count = source["count"];
+	    }
+	}
+	export class BranchTaken {
+	    // Go type: time
+	    timestamp: any;
+	    link_id: string;
+	    baked_index: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new BranchTaken(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.timestamp = this.convertValues(source["timestamp"], null);
+	        this.link_id = source["link_id"];
+	        this.baked_index = source["baked_index"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class MappedBody {
+	    body_id: number;
+	    body_name: string;
+	    signals: BodySignal[];
+	
+	    static createFrom(source: any = {}) {
+	        return new MappedBody(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.body_id = source["body_id"];
+	        this.body_name = source["body_name"];
+	        this.signals = this.convertValues(source["signals"], BodySignal);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ScannedBody {
+	    body_id: number;
+	    body_name: string;
+	    type: string;
+	    terraform_state?: string;
+	    landable: boolean;
+	    was_discovered: boolean;
+	    was_mapped: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ScannedBody(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.body_id = source["body_id"];
+	        this.body_name = source["body_name"];
+	        this.type = source["type"];
+	        this.terraform_state = source["terraform_state"];
+	        this.landable = source["landable"];
+	        this.was_discovered = source["was_discovered"];
+	        this.was_mapped = source["was_mapped"];
+	    }
+	}
+	export class SystemExploration {
+	    body_count: number;
+	    non_body_count: number;
+	    honked: boolean;
+	    all_bodies_found: boolean;
+	    scanned_bodies: ScannedBody[];
+	    mapped_bodies: MappedBody[];
+	
+	    static createFrom(source: any = {}) {
+	        return new SystemExploration(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.body_count = source["body_count"];
+	        this.non_body_count = source["non_body_count"];
+	        this.honked = source["honked"];
+	        this.all_bodies_found = source["all_bodies_found"];
+	        this.scanned_bodies = this.convertValues(source["scanned_bodies"], ScannedBody);
+	        this.mapped_bodies = this.convertValues(source["mapped_bodies"], MappedBody);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class DeathRecord {
+	    // Go type: time
+	    died_at: any;
+	    system_name: string;
+	    system_id: number;
+	    killed_by?: string;
+	    resurrect?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new DeathRecord(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.died_at = this.convertValues(source["died_at"], null);
+	        this.system_name = source["system_name"];
+	        this.system_id = source["system_id"];
+	        this.killed_by = source["killed_by"];
+	        this.resurrect = source["resurrect"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ShipHealth {
+	    hull?: number;
+	    fsd?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ShipHealth(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.hull = source["hull"];
+	        this.fsd = source["fsd"];
+	    }
+	}
+	export class FuelScoopRecord {
+	    scooped: number;
+	    duration: number;
+	    start_fuel: number;
+	    end_fuel: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new FuelScoopRecord(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.scooped = source["scooped"];
+	        this.duration = source["duration"];
+	        this.start_fuel = source["start_fuel"];
+	        this.end_fuel = source["end_fuel"];
+	    }
 	}
 	export class JumpHistoryEntry {
 	    // Go type: time
@@ -180,8 +399,17 @@ export namespace models {
 	    distance: number;
 	    fuel_used: number;
 	    fuel_in_tank: number;
+	    fuel_scoop?: FuelScoopRecord;
+	    health?: ShipHealth;
+	    fsd_boost?: FSDBoost;
+	    carrier?: boolean;
 	    expected: boolean;
 	    synthetic: boolean;
+	    off_expedition?: boolean;
+	    death?: DeathRecord;
+	    resumed?: boolean;
+	    erroneous?: boolean;
+	    exploration?: SystemExploration;
 	
 	    static createFrom(source: any = {}) {
 	        return new JumpHistoryEntry(source);
@@ -196,8 +424,200 @@ export namespace models {
 	        this.distance = source["distance"];
 	        this.fuel_used = source["fuel_used"];
 	        this.fuel_in_tank = source["fuel_in_tank"];
+	        this.fuel_scoop = this.convertValues(source["fuel_scoop"], FuelScoopRecord);
+	        this.health = this.convertValues(source["health"], ShipHealth);
+	        this.fsd_boost = source["fsd_boost"];
+	        this.carrier = source["carrier"];
 	        this.expected = source["expected"];
 	        this.synthetic = source["synthetic"];
+	        this.off_expedition = source["off_expedition"];
+	        this.death = this.convertValues(source["death"], DeathRecord);
+	        this.resumed = source["resumed"];
+	        this.erroneous = source["erroneous"];
+	        this.exploration = this.convertValues(source["exploration"], SystemExploration);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Correction {
+	    // Go type: time
+	    timestamp: any;
+	    kind: 'set_position'|'insert_skipped'|'delete_entry'|'mark_entry';
+	    prev_baked_index: number;
+	    baked_index: number;
+	    history_index?: number;
+	    entry?: JumpHistoryEntry;
+	    inserted?: number;
+	    note?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Correction(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.timestamp = this.convertValues(source["timestamp"], null);
+	        this.kind = source["kind"];
+	        this.prev_baked_index = source["prev_baked_index"];
+	        this.baked_index = source["baked_index"];
+	        this.history_index = source["history_index"];
+	        this.entry = this.convertValues(source["entry"], JumpHistoryEntry);
+	        this.inserted = source["inserted"];
+	        this.note = source["note"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class PlaySession {
+	    // Go type: time
+	    start: any;
+	    // Go type: time
+	    end: any;
+	    jumps: number;
+	    duration: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PlaySession(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.start = this.convertValues(source["start"], null);
+	        this.end = this.convertValues(source["end"], null);
+	        this.jumps = source["jumps"];
+	        this.duration = source["duration"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ExpeditionStats {
+	    // Go type: time
+	    computed_at: any;
+	    jumps: number;
+	    total_distance: number;
+	    on_route_distance: number;
+	    detours: number;
+	    detour_jumps: number;
+	    detour_distance: number;
+	    average_jump: number;
+	    sessions: PlaySession[];
+	    play_time: number;
+	    jumps_per_hour: number;
+	    fuel_used: number;
+	    fuel_scooped: number;
+	    boosted_jumps: number;
+	    deaths: number;
+	    completion: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ExpeditionStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.computed_at = this.convertValues(source["computed_at"], null);
+	        this.jumps = source["jumps"];
+	        this.total_distance = source["total_distance"];
+	        this.on_route_distance = source["on_route_distance"];
+	        this.detours = source["detours"];
+	        this.detour_jumps = source["detour_jumps"];
+	        this.detour_distance = source["detour_distance"];
+	        this.average_jump = source["average_jump"];
+	        this.sessions = this.convertValues(source["sessions"], PlaySession);
+	        this.play_time = source["play_time"];
+	        this.jumps_per_hour = source["jumps_per_hour"];
+	        this.fuel_used = source["fuel_used"];
+	        this.fuel_scooped = source["fuel_scooped"];
+	        this.boosted_jumps = source["boosted_jumps"];
+	        this.deaths = source["deaths"];
+	        this.completion = source["completion"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Lap {
+	    number: number;
+	    // Go type: time
+	    start: any;
+	    // Go type: time
+	    end?: any;
+	    stats?: ExpeditionStats;
+	
+	    static createFrom(source: any = {}) {
+	        return new Lap(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.number = source["number"];
+	        this.start = this.convertValues(source["start"], null);
+	        this.end = this.convertValues(source["end"], null);
+	        this.stats = this.convertValues(source["stats"], ExpeditionStats);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -222,6 +642,7 @@ export namespace models {
 	    id: string;
 	    from: RoutePosition;
 	    to: RoutePosition;
+	    alternative?: boolean;
 	
 	    static createFrom(source: any = {}) {
 	        return new Link(source);
@@ -232,6 +653,7 @@ export namespace models {
 	        this.id = source["id"];
 	        this.from = this.convertValues(source["from"], RoutePosition);
 	        this.to = this.convertValues(source["to"], RoutePosition);
+	        this.alternative = source["alternative"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -266,6 +688,40 @@ export namespace models {
 	        this.jump_index = source["jump_index"];
 	    }
 	}
+	export class Pause {
+	    // Go type: time
+	    from: any;
+	    // Go type: time
+	    to?: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new Pause(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.from = this.convertValues(source["from"], null);
+	        this.to = this.convertValues(source["to"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
 	export class Expedition {
 	    id: string;
 	    name: string;
@@ -273,18 +729,26 @@ export namespace models {
 	    created_at: any;
 	    // Go type: time
 	    last_updated: any;
-	    status: 'planned'|'active'|'completed'|'ended';
+	    status: 'planned'|'active'|'paused'|'completed'|'ended';
+	    commander_fid?: string;
 	    // Go type: time
 	    started_on?: any;
 	    // Go type: time
 	    ended_on?: any;
+	    pauses?: Pause[];
+	    count_carrier_jumps?: boolean;
 	    start?: RoutePosition;
 	    routes: string[];
 	    links: Link[];
 	    baked_route_id?: string;
 	    current_baked_index: number;
 	    baked_loop_back_index?: number;
+	    max_laps?: number;
+	    laps?: Lap[];
 	    jump_history: JumpHistoryEntry[];
+	    stats?: ExpeditionStats;
+	    corrections?: Correction[];
+	    branches?: BranchTaken[];
 	
 	    static createFrom(source: any = {}) {
 	        return new Expedition(source);
@@ -297,15 +761,23 @@ export namespace models {
 	        this.created_at = this.convertValues(source["created_at"], null);
 	        this.last_updated = this.convertValues(source["last_updated"], null);
 	        this.status = source["status"];
+	        this.commander_fid = source["commander_fid"];
 	        this.started_on = this.convertValues(source["started_on"], null);
 	        this.ended_on = this.convertValues(source["ended_on"], null);
+	        this.pauses = this.convertValues(source["pauses"], Pause);
+	        this.count_carrier_jumps = source["count_carrier_jumps"];
 	        this.start = this.convertValues(source["start"], RoutePosition);
 	        this.routes = source["routes"];
 	        this.links = this.convertValues(source["links"], Link);
 	        this.baked_route_id = source["baked_route_id"];
 	        this.current_baked_index = source["current_baked_index"];
 	        this.baked_loop_back_index = source["baked_loop_back_index"];
+	        this.max_laps = source["max_laps"];
+	        this.laps = this.convertValues(source["laps"], Lap);
 	        this.jump_history = this.convertValues(source["jump_history"], JumpHistoryEntry);
+	        this.stats = this.convertValues(source["stats"], ExpeditionStats);
+	        this.corrections = this.convertValues(source["corrections"], Correction);
+	        this.branches = this.convertValues(source["branches"], BranchTaken);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -326,14 +798,17 @@ export namespace models {
 		    return a;
 		}
 	}
+	
 	export class ExpeditionSummary {
 	    id: string;
 	    name: string;
-	    status: 'planned'|'active'|'completed'|'ended';
+	    status: 'planned'|'active'|'paused'|'completed'|'ended';
 	    // Go type: time
 	    created_at: any;
 	    // Go type: time
 	    last_updated: any;
+	    fuel_scooped?: number;
+	    scoop_duration?: number;
 	
 	    static createFrom(source: any = {}) {
 	        return new ExpeditionSummary(source);
@@ -346,6 +821,94 @@ export namespace models {
 	        this.status = source["status"];
 	        this.created_at = this.convertValues(source["created_at"], null);
 	        this.last_updated = this.convertValues(source["last_updated"], null);
+	        this.fuel_scooped = source["fuel_scooped"];
+	        this.scoop_duration = source["scoop_duration"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class FeasibilityIssue {
+	    kind: 'out_of_range'|'out_of_fuel';
+	    severity: 'warning'|'error';
+	    from_index: number;
+	    to_index: number;
+	    system_name: string;
+	    distance: number;
+	    range?: number;
+	    suggested_boost?: FSDBoost;
+	    message: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new FeasibilityIssue(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.kind = source["kind"];
+	        this.severity = source["severity"];
+	        this.from_index = source["from_index"];
+	        this.to_index = source["to_index"];
+	        this.system_name = source["system_name"];
+	        this.distance = source["distance"];
+	        this.range = source["range"];
+	        this.suggested_boost = source["suggested_boost"];
+	        this.message = source["message"];
+	    }
+	}
+	export class InjectionCount {
+	    basic: number;
+	    standard: number;
+	    premium: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new InjectionCount(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.basic = source["basic"];
+	        this.standard = source["standard"];
+	        this.premium = source["premium"];
+	    }
+	}
+	export class FeasibilityReport {
+	    ship: string;
+	    max_range: number;
+	    fuel_capacity: number;
+	    issues: FeasibilityIssue[];
+	    injections: InjectionCount;
+	    suggested_injections: InjectionCount;
+	    blocking: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new FeasibilityReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.ship = source["ship"];
+	        this.max_range = source["max_range"];
+	        this.fuel_capacity = source["fuel_capacity"];
+	        this.issues = this.convertValues(source["issues"], FeasibilityIssue);
+	        this.injections = this.convertValues(source["injections"], InjectionCount);
+	        this.suggested_injections = this.convertValues(source["suggested_injections"], InjectionCount);
+	        this.blocking = source["blocking"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -382,9 +945,16 @@ export namespace models {
 	}
 	
 	
+	
+	
+	
 	export class Loadout {
 	    // Go type: time
 	    timestamp: any;
+	    ship_id: number;
+	    ship?: string;
+	    ship_name?: string;
+	    ship_ident?: string;
 	    unladen_mass: number;
 	    fuel_capacity: FuelCapacity;
 	    // Go type: struct { Item string "json:\"item\""; OptimalMass *float64 "json:\"optimal_mass,omitempty\""; MaxFuelPerJump *float64 "json:\"max_fuel_per_jump,omitempty\"" }
@@ -398,6 +968,10 @@ export namespace models {
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.timestamp = this.convertValues(source["timestamp"], null);
+	        this.ship_id = source["ship_id"];
+	        this.ship = source["ship"];
+	        this.ship_name = source["ship_name"];
+	        this.ship_ident = source["ship_ident"];
 	        this.unladen_mass = source["unladen_mass"];
 	        this.fuel_capacity = this.convertValues(source["fuel_capacity"], FuelCapacity);
 	        this.fsd = this.convertValues(source["fsd"], Object);
@@ -422,6 +996,9 @@ export namespace models {
 		    return a;
 		}
 	}
+	
+	
+	
 	export class RouteJump {
 	    system_name: string;
 	    system_id: number;
@@ -516,11 +1093,55 @@ export namespace models {
 		}
 	}
 	
+	
+	
+	
 
 }
 
 export namespace services {
 	
+	export enum HazardKind {
+	    BEING_INTERDICTED = "being_interdicted",
+	    IN_DANGER = "in_danger",
+	    LOW_FUEL = "low_fuel",
+	    OVERHEATING_SCOOPING = "overheating_scooping",
+	}
+	export enum HistoryDiffKind {
+	    ADDED = "added",
+	    CHANGED = "changed",
+	    REMOVED = "removed",
+	}
+	export class ETAPrediction {
+	    remainingJumps: number;
+	    samples: number;
+	    jumpInterval: number;
+	    refuelStops: number;
+	    refuelTime: number;
+	    boostStops: number;
+	    boostTime: number;
+	    remainingTime: number;
+	    sessionLength: number;
+	    remainingSessions: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ETAPrediction(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.remainingJumps = source["remainingJumps"];
+	        this.samples = source["samples"];
+	        this.jumpInterval = source["jumpInterval"];
+	        this.refuelStops = source["refuelStops"];
+	        this.refuelTime = source["refuelTime"];
+	        this.boostStops = source["boostStops"];
+	        this.boostTime = source["boostTime"];
+	        this.remainingTime = source["remainingTime"];
+	        this.sessionLength = source["sessionLength"];
+	        this.remainingSessions = source["remainingSessions"];
+	    }
+	}
 	export class GalaxySystem {
 	    Id: number;
 	    Name: string;
@@ -611,6 +1232,82 @@ export namespace services {
 		    return a;
 		}
 	}
+	export class HistoryDiffEntry {
+	    kind: HistoryDiffKind;
+	    old?: models.JumpHistoryEntry;
+	    new?: models.JumpHistoryEntry;
+	
+	    static createFrom(source: any = {}) {
+	        return new HistoryDiffEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.kind = source["kind"];
+	        this.old = this.convertValues(source["old"], models.JumpHistoryEntry);
+	        this.new = this.convertValues(source["new"], models.JumpHistoryEntry);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class HistoryRebuild {
+	    expedition_id: string;
+	    jump_history: models.JumpHistoryEntry[];
+	    current_baked_index: number;
+	    laps?: models.Lap[];
+	    filled_gaps: number;
+	    unfilled_gaps: number;
+	    diff: HistoryDiffEntry[];
+	
+	    static createFrom(source: any = {}) {
+	        return new HistoryRebuild(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.expedition_id = source["expedition_id"];
+	        this.jump_history = this.convertValues(source["jump_history"], models.JumpHistoryEntry);
+	        this.current_baked_index = source["current_baked_index"];
+	        this.laps = this.convertValues(source["laps"], models.Lap);
+	        this.filled_gaps = source["filled_gaps"];
+	        this.unfilled_gaps = source["unfilled_gaps"];
+	        this.diff = this.convertValues(source["diff"], HistoryDiffEntry);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
 
 }
 
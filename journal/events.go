@@ -1,20 +1,78 @@
 package journal
 
 import (
+	"ed-expedition/models"
 	"encoding/json"
+	"strings"
 	"time"
 )
 
 type EventType string
 
 const (
-	Loadout   EventType = "Loadout"
-	FSDJump   EventType = "FSDJump"
-	FSDTarget EventType = "FSDTarget"
-	Location  EventType = "Location"
-	StartJump EventType = "StartJump"
+	Fileheader EventType = "Fileheader"
+	Commander  EventType = "Commander"
+	LoadGame   EventType = "LoadGame"
+	Loadout    EventType = "Loadout"
+	FSDJump    EventType = "FSDJump"
+	FSDTarget  EventType = "FSDTarget"
+	Location   EventType = "Location"
+	StartJump  EventType = "StartJump"
+
+	CarrierJump EventType = "CarrierJump"
+
+	ShipyardSwap    EventType = "ShipyardSwap"
+	ShipyardNew     EventType = "ShipyardNew"
+	SellShipOnRebuy EventType = "SellShipOnRebuy"
+
+	FuelScoop    EventType = "FuelScoop"
+	JetConeBoost EventType = "JetConeBoost"
+	Synthesis    EventType = "Synthesis"
+
+	HullDamage  EventType = "HullDamage"
+	AfmuRepairs EventType = "AfmuRepairs"
+	Repair      EventType = "Repair"
+
+	Died      EventType = "Died"
+	Resurrect EventType = "Resurrect"
+
+	NavRoute      EventType = "NavRoute"
+	NavRouteClear EventType = "NavRouteClear"
+
+	Scan              EventType = "Scan"
+	FSSDiscoveryScan  EventType = "FSSDiscoveryScan"
+	FSSAllBodiesFound EventType = "FSSAllBodiesFound"
+	SAASignalsFound   EventType = "SAASignalsFound"
 )
 
+// CommanderEvent is published for both Commander and LoadGame events, which
+// the game writes whenever a commander is loaded.
+type CommanderEvent struct {
+	Timestamp time.Time `json:"timestamp"`
+	Event     EventType `json:"event"`
+	FID       string    `json:"FID"`
+	Name      string    `json:"Name"`
+}
+
+type LoadGameEvent struct {
+	Timestamp    time.Time `json:"timestamp"`
+	Event        EventType `json:"event"`
+	FID          string    `json:"FID"`
+	Commander    string    `json:"Commander"`
+	Horizons     bool      `json:"Horizons"`
+	Odyssey      bool      `json:"Odyssey"`
+	Ship         string    `json:"Ship"`
+	ShipID       int       `json:"ShipID"`
+	ShipName     string    `json:"ShipName"`
+	ShipIdent    string    `json:"ShipIdent"`
+	FuelLevel    float64   `json:"FuelLevel"`
+	FuelCapacity float64   `json:"FuelCapacity"`
+	GameMode     string    `json:"GameMode"`
+	Group        string    `json:"Group,omitempty"`
+	Credits      int64     `json:"Credits"`
+	Loan         int64     `json:"Loan"`
+}
+
 type LoadoutEvent struct {
 	Timestamp     time.Time `json:"timestamp"`
 	Event         EventType `json:"event"`
@@ -34,6 +92,9 @@ type LoadoutEvent struct {
 	} `json:"FuelCapacity"`
 	Rebuy   int             `json:"Rebuy"`
 	Modules []LoadoutModule `json:"Modules"`
+
+	// FID of the commander that was logged in, set by the watcher
+	CommanderFID string `json:"-"`
 }
 type LoadoutModule struct {
 	Slot         string  `json:"Slot"`
@@ -72,6 +133,47 @@ func LoadoutEventFromJson(data []byte) (*LoadoutEvent, error) {
 	return &loadoutEvent, nil
 }
 
+// ShipyardSwapEvent is written when the commander switches to a stored ship
+type ShipyardSwapEvent struct {
+	Timestamp    time.Time `json:"timestamp"`
+	Event        EventType `json:"event"`
+	ShipType     string    `json:"ShipType"`
+	ShipID       int       `json:"ShipID"`
+	StoreOldShip string    `json:"StoreOldShip,omitempty"`
+	StoreShipID  *int      `json:"StoreShipID,omitempty"`
+	SellOldShip  string    `json:"SellOldShip,omitempty"`
+	SellShipID   *int      `json:"SellShipID,omitempty"`
+	MarketID     int64     `json:"MarketID"`
+
+	// FID of the commander that was logged in, set by the watcher
+	CommanderFID string `json:"-"`
+}
+
+// ShipyardNewEvent is written after buying a ship, the commander is now in it
+type ShipyardNewEvent struct {
+	Timestamp time.Time `json:"timestamp"`
+	Event     EventType `json:"event"`
+	ShipType  string    `json:"ShipType"`
+	NewShipID int       `json:"NewShipID"`
+
+	// FID of the commander that was logged in, set by the watcher
+	CommanderFID string `json:"-"`
+}
+
+// SellShipOnRebuyEvent is written when a stored ship is sold to pay for a
+// rebuy after death
+type SellShipOnRebuyEvent struct {
+	Timestamp  time.Time `json:"timestamp"`
+	Event      EventType `json:"event"`
+	ShipType   string    `json:"ShipType"`
+	System     string    `json:"System"`
+	SellShipID int       `json:"SellShipId"`
+	ShipPrice  int64     `json:"ShipPrice"`
+
+	// FID of the commander that was logged in, set by the watcher
+	CommanderFID string `json:"-"`
+}
+
 type FSDJumpEvent struct {
 	Timestamp     time.Time `json:"timestamp"`
 	Event         EventType `json:"event"`
@@ -97,6 +199,57 @@ type FSDJumpEvent struct {
 	JumpDist                     float64   `json:"JumpDist"`
 	FuelUsed                     float64   `json:"FuelUsed"`
 	FuelLevel                    float64   `json:"FuelLevel"`
+
+	// FID of the commander that was logged in, set by the watcher
+	CommanderFID string `json:"-"`
+	// Boost picked up since the previous jump, set by the watcher
+	Boost *models.FSDBoost `json:"-"`
+	// Set when converted from a CarrierJumpEvent
+	Carrier bool `json:"-"`
+}
+
+// InOwnShip reports whether the commander jumped in their own ship, as opposed
+// to riding a taxi or crewing on someone else's ship.
+func (e *FSDJumpEvent) InOwnShip() bool {
+	return !e.Taxi && !e.Multicrew
+}
+
+// CarrierJumpEvent is written when the fleet carrier the commander is docked
+// on jumps to another system
+type CarrierJumpEvent struct {
+	Timestamp     time.Time `json:"timestamp"`
+	Event         EventType `json:"event"`
+	Docked        bool      `json:"Docked"`
+	StationName   string    `json:"StationName"`
+	StationType   string    `json:"StationType"`
+	MarketID      int64     `json:"MarketID"`
+	StarSystem    string    `json:"StarSystem"`
+	SystemAddress int64     `json:"SystemAddress"`
+	// [x, y, z], in light years
+	StarPos  []float64 `json:"StarPos"`
+	Body     string    `json:"Body"`
+	BodyID   int       `json:"BodyID"`
+	BodyType string    `json:"BodyType"`
+
+	// FID of the commander that was logged in, set by the watcher
+	CommanderFID string `json:"-"`
+}
+
+// FSDJump returns the carrier jump as a jump of the commander. There is no
+// jump distance in the event and the ship uses no fuel.
+func (e *CarrierJumpEvent) FSDJump() *FSDJumpEvent {
+	return &FSDJumpEvent{
+		Timestamp:     e.Timestamp,
+		Event:         CarrierJump,
+		StarSystem:    e.StarSystem,
+		SystemAddress: e.SystemAddress,
+		StarPos:       e.StarPos,
+		Body:          e.Body,
+		BodyID:        e.BodyID,
+		BodyType:      e.BodyType,
+		CommanderFID:  e.CommanderFID,
+		Carrier:       true,
+	}
 }
 
 func FSDJumpEventFromJson(data []byte) (*FSDJumpEvent, error) {
@@ -133,6 +286,7 @@ type LocationEvent struct {
 	Docked                       bool      `json:"Docked"`
 	Taxi                         bool      `json:"Taxi"`
 	Multicrew                    bool      `json:"Multicrew"`
+	OnFoot                       bool      `json:"OnFoot"`
 	StarSystem                   string    `json:"StarSystem"`
 	SystemAddress                int64     `json:"SystemAddress"`
 	StarPos                      []float64 `json:"StarPos"`
@@ -149,6 +303,149 @@ type LocationEvent struct {
 	Body                         string    `json:"Body"`
 	BodyID                       int       `json:"BodyID"`
 	BodyType                     string    `json:"BodyType"`
+
+	// FID of the commander that was logged in, set by the watcher
+	CommanderFID string `json:"-"`
+	// Set by the watcher on the first location after dying and resurrecting,
+	// the location is where the commander respawned
+	Died      *DiedEvent      `json:"-"`
+	Resurrect *ResurrectEvent `json:"-"`
+}
+
+// InOwnShip reports whether the commander is in their own ship. On foot we
+// can't tell where the ship is, it may have been left behind after a taxi ride.
+func (e *LocationEvent) InOwnShip() bool {
+	return !e.Taxi && !e.Multicrew && !e.OnFoot
+}
+
+// FuelScoopEvent is written when the commander stops scooping or the tank is
+// full
+type FuelScoopEvent struct {
+	Timestamp time.Time `json:"timestamp"`
+	Event     EventType `json:"event"`
+	Scooped   float64   `json:"Scooped"`
+	// Fuel in the main tank after scooping
+	Total float64 `json:"Total"`
+
+	// FID of the commander that was logged in, set by the watcher
+	CommanderFID string `json:"-"`
+}
+
+// JetConeBoostEvent is written when supercharging the FSD in the jet cone of
+// a neutron star or white dwarf
+type JetConeBoostEvent struct {
+	Timestamp  time.Time `json:"timestamp"`
+	Event      EventType `json:"event"`
+	BoostValue float64   `json:"BoostValue"`
+}
+
+// FSDBoost returns the boost gained, false if the value matches neither a
+// neutron star nor a white dwarf
+func (e *JetConeBoostEvent) FSDBoost() (models.FSDBoost, bool) {
+	switch {
+	case e.BoostValue >= models.FSDBoostNeutron.RangeMultiplier():
+		return models.FSDBoostNeutron, true
+	case e.BoostValue >= models.FSDBoostWhiteDwarf.RangeMultiplier():
+		return models.FSDBoostWhiteDwarf, true
+	}
+	return models.FSDBoostNone, false
+}
+
+type SynthesisEvent struct {
+	Timestamp time.Time `json:"timestamp"`
+	Event     EventType `json:"event"`
+	Name      string    `json:"Name"`
+}
+
+// FSDBoost returns the boost gained, false if something other than an FSD
+// injection was synthesised
+func (e *SynthesisEvent) FSDBoost() (models.FSDBoost, bool) {
+	switch e.Name {
+	case "FSD Basic":
+		return models.FSDBoostInjectionBasic, true
+	case "FSD Standard":
+		return models.FSDBoostInjectionStandard, true
+	case "FSD Premium":
+		return models.FSDBoostInjectionPremium, true
+	default:
+		return models.FSDBoostNone, false
+	}
+}
+
+type HullDamageEvent struct {
+	Timestamp time.Time `json:"timestamp"`
+	Event     EventType `json:"event"`
+	// 0 to 1
+	Health      float64 `json:"Health"`
+	PlayerPilot bool    `json:"PlayerPilot"`
+	// Damage to a fighter rather than the ship
+	Fighter bool `json:"Fighter"`
+
+	// FID of the commander that was logged in, set by the watcher
+	CommanderFID string `json:"-"`
+}
+
+type AfmuRepairsEvent struct {
+	Timestamp       time.Time `json:"timestamp"`
+	Event           EventType `json:"event"`
+	Module          string    `json:"Module"`
+	ModuleLocalised string    `json:"Module_Localised"`
+	FullyRepaired   bool      `json:"FullyRepaired"`
+	// 0 to 1
+	Health float64 `json:"Health"`
+
+	// FID of the commander that was logged in, set by the watcher
+	CommanderFID string `json:"-"`
+}
+
+// RepairEvent is written when repairing at a station
+type RepairEvent struct {
+	Timestamp time.Time `json:"timestamp"`
+	Event     EventType `json:"event"`
+	// All, Hull, Paint, Wear or a module. Newer versions write Items instead.
+	Item  string   `json:"Item,omitempty"`
+	Items []string `json:"Items,omitempty"`
+	Cost  int64    `json:"Cost"`
+
+	// FID of the commander that was logged in, set by the watcher
+	CommanderFID string `json:"-"`
+}
+
+// Repaired returns everything that was repaired
+func (e *RepairEvent) Repaired() []string {
+	if e.Item != "" {
+		return append([]string{e.Item}, e.Items...)
+	}
+	return e.Items
+}
+
+// IsFSDModule reports whether a module item, as written in Loadout,
+// AfmuRepairs or Repair, is a frame shift drive
+func IsFSDModule(item string) bool {
+	return strings.Contains(strings.ToLower(item), "int_hyperdrive")
+}
+
+type DiedEvent struct {
+	Timestamp time.Time `json:"timestamp"`
+	Event     EventType `json:"event"`
+	// Only present when killed by a single ship
+	KillerName string `json:"KillerName,omitempty"`
+	KillerShip string `json:"KillerShip,omitempty"`
+	// Only present when killed by a wing
+	Killers []struct {
+		Name string `json:"Name"`
+		Ship string `json:"Ship"`
+		Rank string `json:"Rank"`
+	} `json:"Killers,omitempty"`
+}
+
+type ResurrectEvent struct {
+	Timestamp time.Time `json:"timestamp"`
+	Event     EventType `json:"event"`
+	// rebuy, recover or handin
+	Option   string `json:"Option"`
+	Cost     int64  `json:"Cost"`
+	Bankrupt bool   `json:"Bankrupt"`
 }
 
 type JumpType string
@@ -168,3 +465,57 @@ type StartJumpEvent struct {
 	SystemAddress *int64  `json:"SystemAddress,omitempty"`
 	StarClass     *string `json:"StarClass,omitempty"`
 }
+
+type ScanEvent struct {
+	Timestamp             time.Time `json:"timestamp"`
+	Event                 EventType `json:"event"`
+	ScanType              string    `json:"ScanType"`
+	BodyName              string    `json:"BodyName"`
+	BodyID                int       `json:"BodyID"`
+	StarSystem            string    `json:"StarSystem"`
+	SystemAddress         int64     `json:"SystemAddress"`
+	DistanceFromArrivalLS float64   `json:"DistanceFromArrivalLS"`
+	// Only present for stars
+	StarType string `json:"StarType,omitempty"`
+	// Only present for planets and moons
+	PlanetClass    string `json:"PlanetClass,omitempty"`
+	TerraformState string `json:"TerraformState,omitempty"`
+	Landable       bool   `json:"Landable"`
+	WasDiscovered  bool   `json:"WasDiscovered"`
+	WasMapped      bool   `json:"WasMapped"`
+}
+
+type FSSDiscoveryScanEvent struct {
+	Timestamp     time.Time `json:"timestamp"`
+	Event         EventType `json:"event"`
+	Progress      float64   `json:"Progress"`
+	BodyCount     int       `json:"BodyCount"`
+	NonBodyCount  int       `json:"NonBodyCount"`
+	SystemName    string    `json:"SystemName"`
+	SystemAddress int64     `json:"SystemAddress"`
+}
+
+type FSSAllBodiesFoundEvent struct {
+	Timestamp     time.Time `json:"timestamp"`
+	Event         EventType `json:"event"`
+	SystemName    string    `json:"SystemName"`
+	SystemAddress int64     `json:"SystemAddress"`
+	Count         int       `json:"Count"`
+}
+
+type SAASignalsFoundEvent struct {
+	Timestamp     time.Time `json:"timestamp"`
+	Event         EventType `json:"event"`
+	BodyName      string    `json:"BodyName"`
+	BodyID        int       `json:"BodyID"`
+	SystemAddress int64     `json:"SystemAddress"`
+	Signals       []struct {
+		Type          string `json:"Type"`
+		TypeLocalised string `json:"Type_Localised"`
+		Count         int    `json:"Count"`
+	} `json:"Signals"`
+	Genuses []struct {
+		Genus          string `json:"Genus"`
+		GenusLocalised string `json:"Genus_Localised"`
+	} `json:"Genuses,omitempty"`
+}
@@ -0,0 +1,70 @@
+package journal
+
+import (
+	"ed-expedition/models"
+	"slices"
+	"time"
+
+	wailsLogger "github.com/wailsapp/wails/v2/pkg/logger"
+)
+
+// Dir returns the journal directory being watched
+func (jw *Watcher) Dir() string {
+	return jw.dir
+}
+
+// ReadJumps returns the FSDJump events written between since and until, in
+// journal order. CarrierJump events are included as jumps marked Carrier. It
+// runs Sync on a throwaway watcher, so events are parsed and stamped with the
+// commander exactly as they are live. A zero until reads to the end of the
+// latest journal.
+func ReadJumps(dir string, since, until time.Time, logger wailsLogger.Logger) ([]*FSDJumpEvent, error) {
+	watcher, err := NewWatcher(dir, logger)
+	if err != nil {
+		return nil, err
+	}
+	defer watcher.Close()
+
+	jumpChan := watcher.FSDJump.Subscribe()
+	done := make(chan []*FSDJumpEvent)
+	go func() {
+		jumps := make([]*FSDJumpEvent, 0, 256)
+		for event := range jumpChan {
+			if until.IsZero() || !event.Timestamp.After(until) {
+				jumps = append(jumps, event)
+			}
+		}
+		done <- jumps
+	}()
+
+	carrierJumpChan := watcher.CarrierJump.Subscribe()
+	carrierDone := make(chan []*FSDJumpEvent)
+	go func() {
+		jumps := []*FSDJumpEvent{}
+		for event := range carrierJumpChan {
+			if until.IsZero() || !event.Timestamp.After(until) {
+				jumps = append(jumps, event.FSDJump())
+			}
+		}
+		carrierDone <- jumps
+	}()
+
+	err = watcher.Sync(models.JournalSync{Timestamp: since})
+	watcher.FSDJump.Unsubscribe(jumpChan)
+	watcher.CarrierJump.Unsubscribe(carrierJumpChan)
+	jumps := <-done
+	carrierJumps := <-carrierDone
+
+	if err != nil {
+		return nil, err
+	}
+
+	// The channels are read separately, merge them back into journal order
+	if len(carrierJumps) > 0 {
+		jumps = append(jumps, carrierJumps...)
+		slices.SortStableFunc(jumps, func(a, b *FSDJumpEvent) int {
+			return a.Timestamp.Compare(b.Timestamp)
+		})
+	}
+	return jumps, nil
+}
@@ -0,0 +1,130 @@
+package journal
+
+import (
+	"ed-expedition/models"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+)
+
+// SetIndex makes Sync skip what the index shows it has already processed, and
+// both Sync and the live watcher record what they read in it. save persists
+// the index, it is called after Sync, whenever the live watcher moves on to a
+// new journal and on Close.
+func (jw *Watcher) SetIndex(index *models.JournalIndex, save func(*models.JournalIndex) error) {
+	jw.mu.Lock()
+	defer jw.mu.Unlock()
+	jw.index = index
+	jw.saveIndexFn = save
+}
+
+// saveIndex persists the index, being a cache failing to is only logged
+func (jw *Watcher) saveIndex() {
+	if jw.index == nil || jw.saveIndexFn == nil {
+		return
+	}
+	if err := jw.saveIndexFn(jw.index); err != nil {
+		jw.logger.Warning(fmt.Sprintf("[Watcher] failed to save journal index: %v", err))
+	}
+}
+
+// indexedOffset is where Sync can start reading a journal, right after the
+// lines the index shows are at or before the sync position. 0 reads it all.
+func (jw *Watcher) indexedOffset(journal *JournalName, syncState *models.JournalSync) int64 {
+	if jw.index == nil {
+		return 0
+	}
+	entry, ok := jw.index.Files[journal.name]
+	if !ok {
+		return 0
+	}
+	info, err := os.Stat(path.Join(jw.dir, journal.name))
+	// Journals only grow, a smaller file is not the one that was indexed
+	if err != nil || info.Size() < entry.Offset {
+		return 0
+	}
+
+	processed := entry.LastEvent.Before(syncState.Timestamp) ||
+		(entry.LastEvent.Equal(syncState.Timestamp) && entry.LastLineHash == syncState.EventHash)
+	if !processed {
+		return 0
+	}
+	return entry.Offset
+}
+
+// readJournalFrom reads a journal from offset to its current end
+func (jw *Watcher) readJournalFrom(name string, offset int64) ([]byte, error) {
+	file, err := os.Open(path.Join(jw.dir, name))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	pos, err := file.Seek(offset, io.SeekStart)
+	if err != nil {
+		return nil, fmt.Errorf("failed to seek to %d in %s: %w", offset, name, err)
+	}
+	if pos != offset {
+		return nil, fmt.Errorf("failed to seek in %s, got to %d aimed for %d", name, pos, offset)
+	}
+
+	return io.ReadAll(file)
+}
+
+// indexLines records that the complete lines of a journal between the byte
+// offsets from and to have been read
+func (jw *Watcher) indexLines(name string, from, to int64, lines []parsedLine) {
+	if jw.index == nil || to <= from {
+		return
+	}
+
+	entry, ok := jw.index.Files[name]
+	if from == 0 {
+		journal, err := parseJournalName(name)
+		if err != nil {
+			return
+		}
+		entry = &models.JournalFileInfo{Part: journal.part}
+		if len(lines) > 0 {
+			entry.FirstEvent = lines[0].Timestamp
+			if lines[0].Event == Fileheader {
+				var header struct {
+					Part int `json:"part"`
+				}
+				if err := json.Unmarshal(lines[0].Raw, &header); err == nil && header.Part > 0 {
+					entry.Part = header.Part
+				}
+			}
+		}
+	} else if !ok || entry.Offset != from {
+		// Not read from where the index left off, the entry can't be trusted
+		delete(jw.index.Files, name)
+		return
+	}
+
+	entry.Offset = to
+	if len(lines) > 0 {
+		last := lines[len(lines)-1]
+		entry.LastEvent = last.Timestamp
+		entry.LastLineHash = hashLine(last.Raw)
+	}
+	jw.index.Files[name] = entry
+}
+
+// pruneIndex drops files that are no longer in the journal directory
+func (jw *Watcher) pruneIndex(journals []*JournalName) {
+	if jw.index == nil {
+		return
+	}
+	present := make(map[string]bool, len(journals))
+	for _, journal := range journals {
+		present[journal.name] = true
+	}
+	for name := range jw.index.Files {
+		if !present[name] {
+			delete(jw.index.Files, name)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package journal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"time"
+)
+
+// NavRouteFile is the content of NavRoute.json, which the game writes next to
+// the journals whenever a route is plotted in the galaxy map. A cleared route
+// has no stops.
+// https://elite-journal.readthedocs.io/en/latest/Travel/#navroute
+type NavRouteFile struct {
+	Timestamp time.Time      `json:"timestamp"`
+	Event     EventType      `json:"event"`
+	Route     []NavRouteStop `json:"Route"`
+}
+
+type NavRouteStop struct {
+	StarSystem    string    `json:"StarSystem"`
+	SystemAddress int64     `json:"SystemAddress"`
+	StarPos       []float64 `json:"StarPos"`
+	StarClass     string    `json:"StarClass"`
+}
+
+// Star classes fuel can be scooped from, the KGBFOAM main sequence stars and
+// their giants. Other classes can share the first letter, e.g. AeBe or MS.
+var scoopableStarClasses = map[string]bool{
+	"O": true,
+	"B": true,
+	"A": true,
+	"F": true,
+	"G": true,
+	"K": true,
+	"M": true,
+
+	"B_BlueWhiteSuperGiant": true,
+	"A_BlueWhiteSuperGiant": true,
+	"F_WhiteSuperGiant":     true,
+	"G_WhiteSuperGiant":     true,
+	"K_OrangeGiant":         true,
+	"M_RedGiant":            true,
+	"M_RedSuperGiant":       true,
+}
+
+// Scoopable reports whether the main star is one of the KGBFOAM classes
+func (s NavRouteStop) Scoopable() bool {
+	return scoopableStarClasses[s.StarClass]
+}
+
+var ErrorNoNavRoute = fmt.Errorf("No route is plotted in game")
+
+// ReadNavRoute reads NavRoute.json from the journal directory. Returns
+// ErrorNoNavRoute if the game has not written one or the route was cleared.
+func ReadNavRoute(dir string) (*NavRouteFile, error) {
+	content, err := os.ReadFile(path.Join(dir, "NavRoute.json"))
+	if os.IsNotExist(err) {
+		return nil, ErrorNoNavRoute
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read: %w", err)
+	}
+
+	if len(bytes.TrimSpace(content)) == 0 {
+		return nil, ErrorNoNavRoute
+	}
+
+	var navRoute NavRouteFile
+	if err := json.Unmarshal(content, &navRoute); err != nil {
+		return nil, fmt.Errorf("parse: %w | content: %q", err, string(content))
+	}
+	if len(navRoute.Route) == 0 {
+		return nil, ErrorNoNavRoute
+	}
+
+	return &navRoute, nil
+}
+
+// handleNavRoute reads the route the NavRoute event refers to. The event itself
+// only tells us that NavRoute.json was rewritten. During Sync the file may
+// already hold a newer route, which is fine since only the latest plot matters.
+func (jw *Watcher) handleNavRoute(raw []byte) {
+	var event NavRouteFile
+	if err := json.Unmarshal(raw, &event); err != nil {
+		return
+	}
+
+	if event.Event == NavRouteClear {
+		jw.logger.Trace("[dispatch] Publishing NavRoute: cleared")
+		jw.NavRoute.Publish(&NavRouteFile{Timestamp: event.Timestamp, Event: event.Event, Route: []NavRouteStop{}})
+		return
+	}
+
+	navRoute, err := ReadNavRoute(jw.dir)
+	if err != nil {
+		jw.logger.Error(fmt.Sprintf("Failed to read NavRoute.json: %s", err.Error()))
+		return
+	}
+
+	jw.logger.Trace(fmt.Sprintf("[dispatch] Publishing NavRoute: %d stops", len(navRoute.Route)))
+	jw.NavRoute.Publish(navRoute)
+}
@@ -0,0 +1,126 @@
+package journal
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"time"
+)
+
+const DefaultPollInterval = time.Second
+
+// Number of poll intervals in a row where files changed without any fsnotify
+// event before we give up on fsnotify.
+const pollFallbackThreshold = 2
+
+// fileStamp is what we compare between polls to tell if a file changed
+type fileStamp struct {
+	name    string
+	size    int64
+	modTime time.Time
+}
+
+func statFile(dir, name string) fileStamp {
+	info, err := os.Stat(path.Join(dir, name))
+	if err != nil {
+		return fileStamp{}
+	}
+	return fileStamp{name: name, size: info.Size(), modTime: info.ModTime()}
+}
+
+// SetPollInterval sets how often the journal directory is stat'ed, both to
+// detect that fsnotify isn't working and, once polling, to pick up changes.
+func (jw *Watcher) SetPollInterval(interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	jw.pollMu.Lock()
+	defer jw.pollMu.Unlock()
+	jw.pollIntervalValue = interval
+}
+
+func (jw *Watcher) pollInterval() time.Duration {
+	jw.pollMu.Lock()
+	defer jw.pollMu.Unlock()
+	return jw.pollIntervalValue
+}
+
+// Polling reports whether the watcher has fallen back to polling
+func (jw *Watcher) Polling() bool {
+	return jw.polling.Load()
+}
+
+// poll stats the newest journal and Status.json every poll interval. While
+// fsnotify works it only checks that events arrive for the changes it sees,
+// after switching to polling it handles the changes itself.
+func (jw *Watcher) poll() {
+	prevJournal := statFile(jw.dir, jw.newestJournal())
+	prevStatus := statFile(jw.dir, "Status.json")
+	prevNotified := jw.notified.Load()
+	missed := 0
+
+	for {
+		select {
+		case <-jw.closed:
+			return
+		case <-time.After(jw.pollInterval()):
+		}
+
+		journal := statFile(jw.dir, jw.newestJournal())
+		status := statFile(jw.dir, "Status.json")
+		journalChanged := journal != prevJournal
+		statusChanged := status != prevStatus
+		prevJournal, prevStatus = journal, status
+
+		if !jw.polling.Load() {
+			notified := jw.notified.Load()
+			if (journalChanged || statusChanged) && notified == prevNotified {
+				missed++
+			} else {
+				missed = 0
+			}
+			prevNotified = notified
+
+			if missed < pollFallbackThreshold {
+				continue
+			}
+			jw.logger.Warning(fmt.Sprintf("[Watcher] files in %s change without fsnotify events, falling back to polling every %s", jw.dir, jw.pollInterval()))
+			jw.polling.Store(true)
+			// Anything written since the last handled event is picked up below
+			journalChanged, statusChanged = journal.name != "", status.name != ""
+		}
+
+		if journalChanged && journal.name != "" {
+			jw.handleFileChange(journal.name)
+		}
+		if statusChanged && status.name != "" {
+			jw.handleStatusUpdate()
+		}
+	}
+}
+
+func (jw *Watcher) newestJournal() string {
+	entries, err := os.ReadDir(jw.dir)
+	if err != nil {
+		return ""
+	}
+
+	var newest *JournalName
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || !journalFilePattern.MatchString(entry.Name()) {
+			continue
+		}
+		name, err := parseJournalName(entry.Name())
+		if err != nil {
+			continue
+		}
+		if newest == nil || compareJournalNames(name, newest) > 0 {
+			newest = name
+		}
+	}
+
+	if newest == nil {
+		return ""
+	}
+	return newest.name
+}
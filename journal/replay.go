@@ -0,0 +1,379 @@
+package journal
+
+import (
+	"context"
+	"ed-expedition/lib/slice"
+	"ed-expedition/models"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"slices"
+	"time"
+)
+
+// ReplayEvent is a single raw journal event. Events with the event name
+// "Status" are written to Status.json instead of the journal.
+type ReplayEvent map[string]any
+
+const statusEventName = "Status"
+
+var journalPartPattern = regexp.MustCompile(`^(Journal\.\d{4}-\d{2}-\d{2}T\d{6}\.)(\d+)(\.log)$`)
+
+func (e ReplayEvent) name() string {
+	name, _ := e["event"].(string)
+	return name
+}
+
+func (e ReplayEvent) timestamp() (time.Time, bool) {
+	ts, ok := e["timestamp"].(string)
+	if !ok {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, ts)
+	return t, err == nil
+}
+
+// Replayer writes journal parts and Status.json into a directory, the same way
+// the game does, so the real Watcher can be driven end to end.
+type Replayer struct {
+	dir string
+
+	// Speed scales the gaps between event timestamps; 1 replays in real time,
+	// 60 replays an hour in a minute. When 0, Delay is used between every event.
+	Speed float64
+	Delay time.Duration
+	// MaxDelay caps any single wait between two events, 0 means no cap
+	MaxDelay time.Duration
+	// RewriteTimestamps shifts every timestamp so the first event is written
+	// at the time of replay, keeping the gaps between events.
+	RewriteTimestamps bool
+	// PartSize starts a new journal part after this many events, 0 means
+	// parts are only split at Fileheader events.
+	PartSize int
+	// FileName names the first journal part, later parts count up its part
+	// number. When empty, parts are named after the first event's timestamp.
+	FileName string
+
+	name        string
+	part        int
+	partEvents  int
+	file        *os.File
+	offset      time.Duration
+	offsetKnown bool
+}
+
+func NewReplayer(dir string) *Replayer {
+	return &Replayer{dir: dir}
+}
+
+// CurrentFile returns the path of the journal part currently written to, or
+// an empty string if nothing has been written yet.
+func (r *Replayer) CurrentFile() string {
+	if r.file == nil {
+		return ""
+	}
+	return r.file.Name()
+}
+
+// Replay writes all events with the configured timing. It stops early if ctx
+// is cancelled.
+func (r *Replayer) Replay(ctx context.Context, events []ReplayEvent) error {
+	var prev time.Time
+	for i, event := range events {
+		if i > 0 {
+			if err := r.wait(ctx, prev, event); err != nil {
+				return err
+			}
+		}
+		if ts, ok := event.timestamp(); ok {
+			prev = ts
+		}
+
+		if err := r.Write(event); err != nil {
+			return fmt.Errorf("event %d (%s): %w", i, event.name(), err)
+		}
+	}
+	return nil
+}
+
+func (r *Replayer) wait(ctx context.Context, prev time.Time, event ReplayEvent) error {
+	delay := r.Delay
+	if r.Speed > 0 {
+		delay = 0
+		if ts, ok := event.timestamp(); ok && !prev.IsZero() && ts.After(prev) {
+			delay = time.Duration(float64(ts.Sub(prev)) / r.Speed)
+		}
+	}
+	if r.MaxDelay > 0 && delay > r.MaxDelay {
+		delay = r.MaxDelay
+	}
+	if delay <= 0 {
+		return ctx.Err()
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(delay):
+		return nil
+	}
+}
+
+// Write immediately writes a single event to the journal, or to Status.json
+// for Status events.
+func (r *Replayer) Write(event ReplayEvent) error {
+	event = r.rewriteTimestamp(event)
+
+	line, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+
+	if event.name() == statusEventName {
+		return os.WriteFile(path.Join(r.dir, "Status.json"), line, 0644)
+	}
+
+	if err := r.ensurePart(event); err != nil {
+		return err
+	}
+
+	if _, err := r.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("write: %w", err)
+	}
+	r.partEvents++
+
+	return r.file.Sync()
+}
+
+func (r *Replayer) rewriteTimestamp(event ReplayEvent) ReplayEvent {
+	if !r.RewriteTimestamps {
+		return event
+	}
+	ts, ok := event.timestamp()
+	if !ok {
+		return event
+	}
+	if !r.offsetKnown {
+		r.offset = time.Since(ts)
+		r.offsetKnown = true
+	}
+
+	rewritten := make(ReplayEvent, len(event))
+	for k, v := range event {
+		rewritten[k] = v
+	}
+	rewritten["timestamp"] = ts.Add(r.offset).UTC().Format(time.RFC3339)
+	return rewritten
+}
+
+func (r *Replayer) ensurePart(event ReplayEvent) error {
+	newPart := r.file == nil ||
+		(r.PartSize > 0 && r.partEvents >= r.PartSize) ||
+		(event.name() == string(Fileheader) && r.partEvents > 0)
+	if !newPart {
+		return nil
+	}
+
+	if r.file != nil {
+		if err := r.file.Close(); err != nil {
+			return err
+		}
+	}
+
+	if r.name == "" {
+		start, ok := event.timestamp()
+		if !ok {
+			start = time.Now()
+		}
+		r.name = start.UTC().Format("2006-01-02T150405")
+	}
+	r.part++
+	r.partEvents = 0
+
+	file, err := os.Create(path.Join(r.dir, r.partFileName()))
+	if err != nil {
+		return err
+	}
+	r.file = file
+	return nil
+}
+
+func (r *Replayer) partFileName() string {
+	if r.FileName == "" {
+		return fmt.Sprintf("Journal.%s.%02d.log", r.name, r.part)
+	}
+	if matches := journalPartPattern.FindStringSubmatch(r.FileName); matches != nil {
+		return fmt.Sprintf("%s%02d%s", matches[1], r.part, matches[3])
+	}
+
+	// Not named like a journal, the part number goes before the extension
+	ext := path.Ext(r.FileName)
+	return fmt.Sprintf("%s.%02d%s", r.FileName[:len(r.FileName)-len(ext)], r.part, ext)
+}
+
+func (r *Replayer) Close() error {
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
+
+// LoadReplayEventsFromDir reads every journal in dir, in the order the game
+// wrote them.
+func LoadReplayEventsFromDir(dir string) ([]ReplayEvent, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	journals := make([]*JournalName, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || !journalFilePattern.MatchString(entry.Name()) {
+			continue
+		}
+		name, err := parseJournalName(entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		journals = append(journals, name)
+	}
+	slices.SortFunc(journals, compareJournalNames)
+
+	events := make([]ReplayEvent, 0, 1024)
+	for _, journal := range journals {
+		content, err := os.ReadFile(path.Join(dir, journal.name))
+		if err != nil {
+			return nil, err
+		}
+
+		complete, _ := splitCompleteLines(content)
+		for _, line := range slice.Split(complete, '\n') {
+			if len(line) == 0 {
+				continue
+			}
+			var event ReplayEvent
+			if err := json.Unmarshal(line, &event); err != nil {
+				return nil, fmt.Errorf("%s: %w", journal.name, err)
+			}
+			events = append(events, event)
+		}
+	}
+
+	return events, nil
+}
+
+// LoadReplayEventsFromJson reads a JSON array of journal events
+func LoadReplayEventsFromJson(file string) ([]ReplayEvent, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []ReplayEvent
+	if err := json.Unmarshal(content, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+type RouteEventOptions struct {
+	Start time.Time
+	// Time between two jumps, including charging and the hyperspace tunnel.
+	// Journal timestamps have second precision, so keep it well above that.
+	JumpInterval time.Duration
+	FuelCapacity float64
+}
+
+var ErrorRouteTooShort = errors.New("Route needs at least two jumps to generate events")
+
+// GenerateRouteEvents produces a plausible event stream of a commander flying
+// the given route: FSDTarget, FSD charging status, StartJump and FSDJump for
+// every jump, scooping at scoopable stars when the tank runs low.
+func GenerateRouteEvents(route *models.Route, opts RouteEventOptions) ([]ReplayEvent, error) {
+	if len(route.Jumps) < 2 {
+		return nil, ErrorRouteTooShort
+	}
+	if opts.Start.IsZero() {
+		opts.Start = time.Now()
+	}
+	if opts.JumpInterval <= 0 {
+		opts.JumpInterval = time.Minute
+	}
+	if opts.FuelCapacity <= 0 {
+		opts.FuelCapacity = 32
+	}
+
+	ts := opts.Start.UTC()
+	// Events within a jump are placed at fractions of the jump interval
+	at := func(fraction float64) string {
+		return ts.Add(time.Duration(fraction * float64(opts.JumpInterval))).Format(time.RFC3339)
+	}
+
+	first := route.Jumps[0]
+	fuel := opts.FuelCapacity
+	if first.FuelInTank != nil {
+		fuel = *first.FuelInTank
+	}
+
+	events := make([]ReplayEvent, 0, len(route.Jumps)*6+2)
+	events = append(events,
+		ReplayEvent{"timestamp": at(0), "event": string(Fileheader), "part": 1, "language": "English/UK", "Odyssey": true, "gameversion": "4.0.0.1904", "build": "r307926/r0 "},
+		ReplayEvent{"timestamp": at(0), "event": string(Location), "Docked": false, "Taxi": false, "Multicrew": false, "StarSystem": first.SystemName, "SystemAddress": first.SystemID, "StarPos": starPos(&first)},
+		statusEvent(at(0), FlagInMainShip, 0, fuel),
+	)
+
+	for i := 1; i < len(route.Jumps); i++ {
+		jump := route.Jumps[i]
+		fuelUsed := 2.0
+		if jump.FuelUsed != nil {
+			fuelUsed = *jump.FuelUsed
+		}
+		fuel -= fuelUsed
+
+		events = append(events,
+			ReplayEvent{"timestamp": at(0.1), "event": string(FSDTarget), "Name": jump.SystemName, "SystemAddress": jump.SystemID, "StarClass": "K"},
+			statusEvent(at(0.15), FlagInMainShip|FlagFsdCharging, Flag2HyperdriveCharging, fuel+fuelUsed),
+			ReplayEvent{"timestamp": at(0.25), "event": string(StartJump), "JumpType": string(JumpTypeHyperspace), "Taxi": false, "StarSystem": jump.SystemName, "SystemAddress": jump.SystemID, "StarClass": "K"},
+			ReplayEvent{"timestamp": at(0.5), "event": string(FSDJump), "Taxi": false, "Multicrew": false, "StarSystem": jump.SystemName, "SystemAddress": jump.SystemID, "StarPos": starPos(&jump), "JumpDist": jump.Distance, "FuelUsed": fuelUsed, "FuelLevel": fuel},
+			statusEvent(at(0.52), FlagInMainShip, 0, fuel),
+		)
+
+		if jump.Scoopable && (jump.MustRefuel || fuel < opts.FuelCapacity/2) {
+			events = append(events,
+				statusEvent(at(0.65), FlagInMainShip|FlagScoopingFuel, 0, fuel),
+				ReplayEvent{"timestamp": at(0.85), "event": "FuelScoop", "Scooped": opts.FuelCapacity - fuel, "Total": opts.FuelCapacity},
+				statusEvent(at(0.85), FlagInMainShip, 0, opts.FuelCapacity),
+			)
+			fuel = opts.FuelCapacity
+		}
+
+		ts = ts.Add(opts.JumpInterval)
+	}
+
+	return events, nil
+}
+
+func statusEvent(timestamp string, flags Flags, flags2 Flags2, fuel float64) ReplayEvent {
+	event := ReplayEvent{
+		"timestamp": timestamp,
+		"event":     statusEventName,
+		"Flags":     flags,
+		"Fuel":      map[string]float64{"FuelMain": fuel, "FuelReservoir": 0.5},
+	}
+	if flags2 != 0 {
+		event["Flags2"] = flags2
+	}
+	return event
+}
+
+func starPos(jump *models.RouteJump) []float64 {
+	if jump.Position == nil {
+		return []float64{0, 0, 0}
+	}
+	return []float64{jump.Position.X, jump.Position.Y, jump.Position.Z}
+}
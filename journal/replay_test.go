@@ -0,0 +1,143 @@
+package journal
+
+import (
+	"context"
+	"ed-expedition/models"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ReplayTestSuite struct {
+	suite.Suite
+	tmpDir string
+	route  *models.Route
+}
+
+func (s *ReplayTestSuite) SetupTest() {
+	var err error
+	s.tmpDir, err = os.MkdirTemp("", "journal-replay-test-*")
+	s.Require().NoError(err)
+
+	s.route = &models.Route{
+		Jumps: []models.RouteJump{
+			{SystemName: "Sol", SystemID: 1, Scoopable: true},
+			{SystemName: "Alpha Centauri", SystemID: 2, Distance: 4.4, Scoopable: true},
+			{SystemName: "Luhman 16", SystemID: 3, Distance: 2.9},
+		},
+	}
+}
+
+func (s *ReplayTestSuite) TearDownTest() {
+	if s.tmpDir != "" {
+		os.RemoveAll(s.tmpDir)
+	}
+}
+
+func (s *ReplayTestSuite) TestGenerateRouteEventsTooShort() {
+	_, err := GenerateRouteEvents(&models.Route{Jumps: s.route.Jumps[:1]}, RouteEventOptions{})
+	s.ErrorIs(err, ErrorRouteTooShort)
+}
+
+func (s *ReplayTestSuite) TestReplayRouteDrivesWatcher() {
+	watcher, err := NewWatcher(s.tmpDir, &TestLogger{})
+	s.Require().NoError(err)
+	defer watcher.Close()
+
+	jumpCh := watcher.FSDJump.Subscribe()
+	startJumpCh := watcher.StartJump.Subscribe()
+	chargingCh := watcher.FsdCharging.Subscribe()
+	watcher.Start()
+
+	events, err := GenerateRouteEvents(s.route, RouteEventOptions{
+		Start: time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC),
+	})
+	s.Require().NoError(err)
+
+	charging := make(chan bool, 16)
+	go func() {
+		for c := range chargingCh {
+			charging <- c
+		}
+	}()
+	go func() {
+		for range startJumpCh {
+		}
+	}()
+
+	replayer := NewReplayer(s.tmpDir)
+	// A minute per jump replayed in 0.3s, slow enough for Status.json debouncing
+	replayer.Speed = 200
+	defer replayer.Close()
+	s.Require().NoError(replayer.Replay(context.Background(), events))
+
+	jumps := collectJumpEvents(jumpCh, 2, time.Second)
+	s.Require().Len(jumps, 2)
+	s.Equal("Alpha Centauri", jumps[0].StarSystem)
+	s.Equal(4.4, jumps[0].JumpDist)
+	s.Equal("Luhman 16", jumps[1].StarSystem)
+	s.True(jumps[0].Timestamp.Before(jumps[1].Timestamp))
+
+	sawCharging := false
+	for len(charging) > 0 {
+		if <-charging {
+			sawCharging = true
+		}
+	}
+	s.True(sawCharging, "expected FsdCharging to be published during replay")
+}
+
+func (s *ReplayTestSuite) TestReplaySplitsPartsAndLoadsBack() {
+	events := []ReplayEvent{
+		{"timestamp": "2025-01-01T10:00:00Z", "event": "Fileheader", "part": 1},
+		{"timestamp": "2025-01-01T10:00:01Z", "event": "FSDTarget", "Name": "A", "SystemAddress": 1},
+		{"timestamp": "2025-01-01T10:00:02Z", "event": "Status", "Flags": 16777216},
+		{"timestamp": "2025-01-01T10:00:03Z", "event": "Fileheader", "part": 2},
+		{"timestamp": "2025-01-01T10:00:04Z", "event": "FSDTarget", "Name": "B", "SystemAddress": 2},
+	}
+
+	replayer := NewReplayer(s.tmpDir)
+	s.Require().NoError(replayer.Replay(context.Background(), events))
+	s.Require().NoError(replayer.Close())
+
+	s.FileExists(filepath.Join(s.tmpDir, "Journal.2025-01-01T100000.01.log"))
+	s.FileExists(filepath.Join(s.tmpDir, "Journal.2025-01-01T100000.02.log"))
+	s.FileExists(filepath.Join(s.tmpDir, "Status.json"))
+
+	loaded, err := LoadReplayEventsFromDir(s.tmpDir)
+	s.Require().NoError(err)
+	s.Require().Len(loaded, 4)
+	s.Equal("A", loaded[1]["Name"])
+	s.Equal("Fileheader", loaded[2]["event"])
+	s.Equal("B", loaded[3]["Name"])
+}
+
+func (s *ReplayTestSuite) TestReplayNamesPartsAfterFileName() {
+	events := []ReplayEvent{
+		{"timestamp": "2025-01-01T10:00:00Z", "event": "FSDTarget", "Name": "A", "SystemAddress": 1},
+		{"timestamp": "2025-01-01T10:00:01Z", "event": "FSDTarget", "Name": "B", "SystemAddress": 2},
+	}
+
+	replayer := NewReplayer(s.tmpDir)
+	replayer.FileName = "Journal.2024-10-30T124500.05.log"
+	replayer.PartSize = 1
+	s.Require().NoError(replayer.Replay(context.Background(), events))
+	s.Require().NoError(replayer.Close())
+
+	s.FileExists(filepath.Join(s.tmpDir, "Journal.2024-10-30T124500.01.log"))
+	s.FileExists(filepath.Join(s.tmpDir, "Journal.2024-10-30T124500.02.log"))
+
+	replayer = NewReplayer(s.tmpDir)
+	replayer.FileName = "flight.log"
+	s.Require().NoError(replayer.Replay(context.Background(), events))
+	s.Require().NoError(replayer.Close())
+
+	s.FileExists(filepath.Join(s.tmpDir, "flight.01.log"))
+}
+
+func TestReplayTestSuite(t *testing.T) {
+	suite.Run(t, new(ReplayTestSuite))
+}
@@ -15,21 +15,117 @@ type Flags2 int
 
 // https://elite-journal.readthedocs.io/en/latest/Status%20File.html
 const (
-	FlagScoopingFuel Flags = 1 << 11
-	FlagFsdCharging  Flags = 1 << 17
-	FlagInMainShip   Flags = 1 << 24
+	FlagDocked Flags = 1 << iota
+	FlagLanded
+	FlagLandingGearDown
+	FlagShieldsUp
+	FlagSupercruise
+	FlagFlightAssistOff
+	FlagHardpointsDeployed
+	FlagInWing
+	FlagLightsOn
+	FlagCargoScoopDeployed
+	FlagSilentRunning
+	FlagScoopingFuel
+	FlagSrvHandbrake
+	FlagSrvTurretView
+	FlagSrvTurretRetracted
+	FlagSrvDriveAssist
+	FlagFsdMassLocked
+	FlagFsdCharging
+	FlagFsdCooldown
+	// Below 25% fuel
+	FlagLowFuel
+	// Heat above 100%
+	FlagOverHeating
+	FlagHasLatLong
+	FlagIsInDanger
+	FlagBeingInterdicted
+	FlagInMainShip
+	FlagInFighter
+	FlagInSRV
+	FlagHudInAnalysisMode
+	FlagNightVision
+	FlagAltitudeFromAverageRadius
+	FlagFsdJump
+	FlagSrvHighBeam
 )
 
 const (
-	Flag2HyperdriveCharging Flags2 = 1 << 19
+	Flag2OnFoot Flags2 = 1 << iota
+	Flag2InTaxi
+	Flag2InMulticrew
+	Flag2OnFootInStation
+	Flag2OnFootOnPlanet
+	Flag2AimDownSight
+	Flag2LowOxygen
+	Flag2LowHealth
+	Flag2Cold
+	Flag2Hot
+	Flag2VeryCold
+	Flag2VeryHot
+	Flag2GlideMode
+	Flag2OnFootInHangar
+	Flag2OnFootSocialSpace
+	Flag2OnFootExterior
+	Flag2BreathableAtmosphere
+	Flag2TelepresenceMulticrew
+	Flag2PhysicalMulticrew
+	Flag2HyperdriveCharging
 )
 
+// Status is a snapshot of Status.json. Most fields are only written in some
+// states, e.g. Latitude only near a planet.
 type Status struct {
 	Timestamp time.Time   `json:"timestamp"`
 	Event     string      `json:"event"`
 	Flags     *Flags      `json:"Flags"`
 	Flags2    *Flags2     `json:"Flags2"`
 	Fuel      *FuelStatus `json:"Fuel"`
+
+	// Power distribution to systems, engines and weapons, in half pips
+	Pips      *[3]int  `json:"Pips,omitempty"`
+	FireGroup *int     `json:"FireGroup,omitempty"`
+	GuiFocus  *int     `json:"GuiFocus,omitempty"`
+	Cargo     *float64 `json:"Cargo,omitempty"`
+	// Heat level of the ship, 1 is 100%. Not every game version writes it,
+	// FlagOverHeating is always there
+	Heat *float64 `json:"Heat,omitempty"`
+	// Clean, IllegalCargo, Speeding, Wanted, Hostile, PassengerWanted or
+	// Warrant
+	LegalState  *string            `json:"LegalState,omitempty"`
+	Latitude    *float64           `json:"Latitude,omitempty"`
+	Longitude   *float64           `json:"Longitude,omitempty"`
+	Heading     *float64           `json:"Heading,omitempty"`
+	Altitude    *float64           `json:"Altitude,omitempty"`
+	BodyName    *string            `json:"BodyName,omitempty"`
+	Balance     *int64             `json:"Balance,omitempty"`
+	Destination *StatusDestination `json:"Destination,omitempty"`
+
+	// On foot
+	Oxygen         *float64 `json:"Oxygen,omitempty"`
+	Health         *float64 `json:"Health,omitempty"`
+	Temperature    *float64 `json:"Temperature,omitempty"`
+	SelectedWeapon *string  `json:"SelectedWeapon,omitempty"`
+	Gravity        *float64 `json:"Gravity,omitempty"`
+}
+
+// StatusDestination is the target selected in the galaxy or system map
+type StatusDestination struct {
+	System        int64  `json:"System"`
+	Body          int    `json:"Body"`
+	Name          string `json:"Name"`
+	NameLocalised string `json:"Name_Localised,omitempty"`
+}
+
+// Has reports whether flag is set, false if Flags is missing
+func (s *Status) Has(flag Flags) bool {
+	return s.Flags != nil && binflag.Has(*s.Flags, flag)
+}
+
+// Has2 reports whether flag is set, false if Flags2 is missing
+func (s *Status) Has2(flag Flags2) bool {
+	return s.Flags2 != nil && binflag.Has(*s.Flags2, flag)
 }
 
 type FuelStatus struct {
@@ -57,6 +153,8 @@ func (jw *Watcher) readStatus() (*Status, error) {
 
 func (jw *Watcher) handleStatusUpdate() {
 	jw.logger.Trace("handleStatusUpdate called")
+	jw.statusMu.Lock()
+	defer jw.statusMu.Unlock()
 
 	status, err := jw.readStatus()
 	if err != nil {
@@ -69,17 +167,20 @@ func (jw *Watcher) handleStatusUpdate() {
 		return
 	}
 
-	if !binflag.Has(*status.Flags, FlagInMainShip) {
+	jw.logger.Trace("handleStatusUpdate: publishing status")
+	jw.Status.Publish(status)
+
+	if !status.Has(FlagInMainShip) {
 		jw.logger.Trace("handleStatusUpdate: not in main ship, skipping")
 		return
 	}
 
-	scooping := binflag.Has(*status.Flags, FlagScoopingFuel)
+	scooping := status.Has(FlagScoopingFuel)
 	jw.logger.Trace(fmt.Sprintf("handleStatusUpdate: publishing scooping=%v", scooping))
 	jw.Scooping.Publish(scooping)
 
-	fsdChargingFlag := binflag.Has(*status.Flags, FlagFsdCharging)
-	hyperdriveCFlag := status.Flags2 != nil && binflag.Has(*status.Flags2, Flag2HyperdriveCharging)
+	fsdChargingFlag := status.Has(FlagFsdCharging)
+	hyperdriveCFlag := status.Has2(Flag2HyperdriveCharging)
 	fsdCharging := fsdChargingFlag || hyperdriveCFlag
 
 	fuelStr := "n/a"
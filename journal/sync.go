@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"path"
 	"slices"
 	"strconv"
 	"time"
@@ -19,6 +18,12 @@ func (jw *Watcher) Sync(syncState models.JournalSync) error {
 		return errors.New("Cannot call journal.Watcher.Sync() after the watcher has been started")
 	}
 
+	// Events are attributed to the commander logged in at the sync position
+	// until the journal names another
+	if syncState.CommanderFID != "" {
+		jw.commanderFID = syncState.CommanderFID
+	}
+
 	entries, err := os.ReadDir(jw.dir)
 	if err != nil {
 		return err
@@ -36,12 +41,7 @@ func (jw *Watcher) Sync(syncState models.JournalSync) error {
 		journals = append(journals, name)
 	}
 
-	slices.SortFunc(journals, func(a, b *JournalName) int {
-		if timeDiff := a.time.Compare(b.time); timeDiff != 0 {
-			return timeDiff
-		}
-		return a.part - b.part
-	})
+	slices.SortFunc(journals, compareJournalNames)
 
 	jw.logger.Trace(fmt.Sprintf("[Sync] Found %d journals", len(journals)))
 	for i, j := range journals {
@@ -53,15 +53,15 @@ func (jw *Watcher) Sync(syncState models.JournalSync) error {
 		return nil
 	}
 
-	// TODO: Look for optimization potential
-	//  - Maybe [Heading entry](https://elite-journal.readthedocs.io/en/latest/File%20Format.html#heading-entry)
-	//
+	jw.pruneIndex(journals)
+
 	// Since the timestamp in the journal's filename is from when it was started,
 	// and we get the first one AFTER the provided timestamp 'since', we need to
 	// go back to the journals with next recent timestamp, as these might include
 	// event that are after our provided 'since'
 	// Regardless, since each timestamp of every event handled by 'processData'
 	// is checked against 'since', we'll never process event's we should not.
+	// With an index most of these files are skipped without reading them.
 	cutoff := slices.IndexFunc(journals, func(j *JournalName) bool { return j.time.After(syncState.Timestamp) })
 	jw.logger.Trace(fmt.Sprintf("[Sync] IndexFunc returned: %d", cutoff))
 	if cutoff < 0 {
@@ -85,20 +85,31 @@ func (jw *Watcher) Sync(syncState models.JournalSync) error {
 
 	var lastLine *parsedLine
 	for i, journal := range journals {
-		jw.logger.Trace(fmt.Sprintf("[Sync] Processing journal %d: %s", i, journal.name))
-		content, err := os.ReadFile(path.Join(jw.dir, journal.name))
+		offset := jw.indexedOffset(journal, &syncState)
+		content, err := jw.readJournalFrom(journal.name, offset)
 		if err != nil {
 			return err
 		}
-		jw.logger.Trace(fmt.Sprintf("[Sync] Read %d bytes from %s", len(content), journal.name))
+		jw.logger.Trace(fmt.Sprintf("[Sync] Read %d bytes from %s at %d", len(content), journal.name, offset))
 
-		lines, err := jw.parseLines(content)
-		if err != nil {
-			return err
+		jw.currentFile = journal.name
+
+		// The last journal might still be mid-write; leave any incomplete
+		// trailing line for the live watcher to pick up once it is flushed.
+		complete, _ := splitCompleteLines(content)
+		jw.seek = offset + int64(len(complete))
+		jw.carry = nil
+
+		if len(complete) == 0 {
+			if offset > 0 {
+				jw.logger.Trace(fmt.Sprintf("[Sync] Skipping indexed journal %d: %s", i, journal.name))
+			}
+			continue
 		}
 
-		jw.currentFile = journal.name
-		jw.seek = int64(len(content))
+		jw.logger.Trace(fmt.Sprintf("[Sync] Processing journal %d: %s", i, journal.name))
+		lines := jw.parseLines(complete)
+		jw.indexLines(journal.name, offset, jw.seek, lines)
 
 		lines = jw.filterSyncBoundary(lines, &syncState)
 		if len(lines) == 0 {
@@ -112,6 +123,7 @@ func (jw *Watcher) Sync(syncState models.JournalSync) error {
 	if lastLine != nil {
 		jw.publishSyncState(*lastLine)
 	}
+	jw.saveIndex()
 
 	jw.logger.Trace("[Sync] Complete")
 	return nil
@@ -127,6 +139,13 @@ func (j *JournalName) String() string {
 	return fmt.Sprintf("%s (time: %s, part: %d)", j.name, j.time.Format("2006-01-02T15:04:05"), j.part)
 }
 
+func compareJournalNames(a, b *JournalName) int {
+	if timeDiff := a.time.Compare(b.time); timeDiff != 0 {
+		return timeDiff
+	}
+	return a.part - b.part
+}
+
 func parseJournalName(name string) (*JournalName, error) {
 	matches := journalFilePattern.FindStringSubmatch(name)
 	if matches == nil {
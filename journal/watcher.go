@@ -1,6 +1,7 @@
 package journal
 
 import (
+	"bytes"
 	"ed-expedition/lib/channels"
 	"ed-expedition/lib/slice"
 	"ed-expedition/models"
@@ -11,6 +12,9 @@ import (
 	"os"
 	"path"
 	"regexp"
+	"slices"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/fsnotify/fsnotify"
@@ -22,28 +26,86 @@ var journalFilePattern = regexp.MustCompile(`Journal\.(\d{4}-\d{2}-\d{2}T\d{6})\
 const FanoutChannelTimeout = 200 * time.Millisecond
 
 type Watcher struct {
-	dir         string
-	watcher     *fsnotify.Watcher
+	dir     string
+	watcher *fsnotify.Watcher
+	// Guards the read position below, which the fsnotify and the polling
+	// goroutine both move
+	mu          sync.Mutex
 	currentFile string
 	seek        int64
+	carry       []byte
 	started     bool
 	logger      wailsLogger.Logger
-
+	// FID of the commander the journal is currently written for
+	commanderFID string
+	// Lets Sync skip what it has seen before, optional
+	index       *models.JournalIndex
+	saveIndexFn func(*models.JournalIndex) error
+	// Boost waiting for the next jump, see dispatch of FSDJump
+	boost *models.FSDBoost
+	// Death waiting for the respawn location, see dispatch of Location
+	died      *DiedEvent
+	resurrect *ResurrectEvent
+
+	Commander *channels.FanoutChannel[*CommanderEvent]
+	LoadGame  *channels.FanoutChannel[*LoadGameEvent]
 	Loadout   *channels.FanoutChannel[*LoadoutEvent]
 	FSDJump   *channels.FanoutChannel[*FSDJumpEvent]
 	FSDTarget *channels.FanoutChannel[*FSDTargetEvent]
 	Location  *channels.FanoutChannel[*LocationEvent]
 	StartJump *channels.FanoutChannel[*StartJumpEvent]
+
+	CarrierJump *channels.FanoutChannel[*CarrierJumpEvent]
+
+	FuelScoop *channels.FanoutChannel[*FuelScoopEvent]
+
+	// Damage and repairs
+	HullDamage  *channels.FanoutChannel[*HullDamageEvent]
+	AfmuRepairs *channels.FanoutChannel[*AfmuRepairsEvent]
+	Repair      *channels.FanoutChannel[*RepairEvent]
+
+	// FSD boosts
+	JetConeBoost *channels.FanoutChannel[*JetConeBoostEvent]
+	Synthesis    *channels.FanoutChannel[*SynthesisEvent]
+
+	// Ships
+	ShipyardSwap    *channels.FanoutChannel[*ShipyardSwapEvent]
+	ShipyardNew     *channels.FanoutChannel[*ShipyardNewEvent]
+	SellShipOnRebuy *channels.FanoutChannel[*SellShipOnRebuyEvent]
+
+	// Published with the content of NavRoute.json, empty when cleared
+	NavRoute *channels.FanoutChannel[*NavRouteFile]
+
+	// Exploration
+	Scan              *channels.FanoutChannel[*ScanEvent]
+	FSSDiscoveryScan  *channels.FanoutChannel[*FSSDiscoveryScanEvent]
+	FSSAllBodiesFound *channels.FanoutChannel[*FSSAllBodiesFoundEvent]
+	SAASignalsFound   *channels.FanoutChannel[*SAASignalsFoundEvent]
+
 	SyncState *channels.FanoutChannel[models.JournalSync]
 
+	LineErrors *channels.FanoutChannel[*LineError]
+
 	// Status
-	Scooping            *channels.FanoutChannel[bool]
-	Fuel                *channels.FanoutChannel[*FuelStatus]
-	FsdCharging         *channels.FanoutChannel[bool]
+	Status      *channels.FanoutChannel[*Status]
+	Scooping    *channels.FanoutChannel[bool]
+	Fuel        *channels.FanoutChannel[*FuelStatus]
+	FsdCharging *channels.FanoutChannel[bool]
+	// Guards the Status.json state below
+	statusMu            sync.Mutex
 	prevFsdChargingFlag bool
 	prevHyperdriveCFlag bool
 
 	statusDebounce *time.Timer
+
+	// Polling fallback, see poll.go
+	pollMu            sync.Mutex
+	pollIntervalValue time.Duration
+	polling           atomic.Bool
+	// Number of fsnotify events received, so polling can tell if they arrive
+	notified  atomic.Int64
+	closed    chan struct{}
+	closeOnce sync.Once
 }
 
 func NewWatcher(dir string, logger wailsLogger.Logger) (*Watcher, error) {
@@ -64,13 +126,43 @@ func NewWatcher(dir string, logger wailsLogger.Logger) (*Watcher, error) {
 		currentFile: "",
 		logger:      logger,
 
+		pollIntervalValue: DefaultPollInterval,
+		closed:            make(chan struct{}),
+
+		Commander: channels.NewFanoutChannel[*CommanderEvent]("Commander", 32, FanoutChannelTimeout, logger),
+		LoadGame:  channels.NewFanoutChannel[*LoadGameEvent]("LoadGame", 32, FanoutChannelTimeout, logger),
 		Loadout:   channels.NewFanoutChannel[*LoadoutEvent]("Loadout", 32, FanoutChannelTimeout, logger),
 		FSDJump:   channels.NewFanoutChannel[*FSDJumpEvent]("FSDJump", 32, FanoutChannelTimeout, logger),
 		FSDTarget: channels.NewFanoutChannel[*FSDTargetEvent]("FSDTarget", 32, FanoutChannelTimeout, logger),
 		Location:  channels.NewFanoutChannel[*LocationEvent]("Location", 32, FanoutChannelTimeout, logger),
 		StartJump: channels.NewFanoutChannel[*StartJumpEvent]("StartJump", 32, FanoutChannelTimeout, logger),
+		NavRoute:  channels.NewFanoutChannel[*NavRouteFile]("NavRoute", 8, FanoutChannelTimeout, logger),
+
+		CarrierJump: channels.NewFanoutChannel[*CarrierJumpEvent]("CarrierJump", 32, FanoutChannelTimeout, logger),
+
+		FuelScoop: channels.NewFanoutChannel[*FuelScoopEvent]("FuelScoop", 32, FanoutChannelTimeout, logger),
+
+		HullDamage:  channels.NewFanoutChannel[*HullDamageEvent]("HullDamage", 32, FanoutChannelTimeout, logger),
+		AfmuRepairs: channels.NewFanoutChannel[*AfmuRepairsEvent]("AfmuRepairs", 32, FanoutChannelTimeout, logger),
+		Repair:      channels.NewFanoutChannel[*RepairEvent]("Repair", 8, FanoutChannelTimeout, logger),
+
+		JetConeBoost: channels.NewFanoutChannel[*JetConeBoostEvent]("JetConeBoost", 8, FanoutChannelTimeout, logger),
+		Synthesis:    channels.NewFanoutChannel[*SynthesisEvent]("Synthesis", 8, FanoutChannelTimeout, logger),
+
+		ShipyardSwap:    channels.NewFanoutChannel[*ShipyardSwapEvent]("ShipyardSwap", 8, FanoutChannelTimeout, logger),
+		ShipyardNew:     channels.NewFanoutChannel[*ShipyardNewEvent]("ShipyardNew", 8, FanoutChannelTimeout, logger),
+		SellShipOnRebuy: channels.NewFanoutChannel[*SellShipOnRebuyEvent]("SellShipOnRebuy", 8, FanoutChannelTimeout, logger),
+
+		Scan:              channels.NewFanoutChannel[*ScanEvent]("Scan", 64, FanoutChannelTimeout, logger),
+		FSSDiscoveryScan:  channels.NewFanoutChannel[*FSSDiscoveryScanEvent]("FSSDiscoveryScan", 32, FanoutChannelTimeout, logger),
+		FSSAllBodiesFound: channels.NewFanoutChannel[*FSSAllBodiesFoundEvent]("FSSAllBodiesFound", 32, FanoutChannelTimeout, logger),
+		SAASignalsFound:   channels.NewFanoutChannel[*SAASignalsFoundEvent]("SAASignalsFound", 32, FanoutChannelTimeout, logger),
+
 		SyncState: channels.NewFanoutChannel[models.JournalSync]("SyncState", 1, FanoutChannelTimeout, logger),
 
+		LineErrors: channels.NewFanoutChannel[*LineError]("LineErrors", 8, FanoutChannelTimeout, logger),
+
+		Status:      channels.NewFanoutChannel[*Status]("Status", 0, 5*time.Millisecond, logger),
 		Scooping:    channels.NewFanoutChannel[bool]("Scooping", 0, 5*time.Millisecond, logger),
 		Fuel:        channels.NewFanoutChannel[*FuelStatus]("Fuel", 0, 5*time.Millisecond, logger),
 		FsdCharging: channels.NewFanoutChannel[bool]("FsdCharging", 0, 5*time.Millisecond, logger),
@@ -81,40 +173,82 @@ func (jw *Watcher) Start() {
 	jw.started = true
 	go func() {
 		for e := range jw.watcher.Events {
+			jw.notified.Add(1)
+			if jw.polling.Load() {
+				continue
+			}
+
 			file := path.Base(e.Name)
 
 			if file == "Status.json" {
 				// Elite writes Status.json frequently and non-atomically, often
 				// firing several events per write. Debounce so we only read once
 				// the write has settled, avoiding mid-write reads.
+				jw.statusMu.Lock()
 				if jw.statusDebounce != nil {
 					jw.statusDebounce.Stop()
 				}
 				jw.statusDebounce = time.AfterFunc(10*time.Millisecond, jw.handleStatusUpdate)
+				jw.statusMu.Unlock()
 				continue
 			}
 
-			if file == jw.currentFile {
-				jw.handleJournalUpdate()
-				continue
-			}
+			jw.handleFileChange(file)
+		}
+	}()
+	go jw.poll()
+}
 
-			if !journalFilePattern.MatchString(file) {
-				continue
-			}
+func (jw *Watcher) handleFileChange(file string) {
+	jw.mu.Lock()
+	defer jw.mu.Unlock()
 
-			jw.currentFile = file
-			jw.seek = 0
-			jw.handleJournalUpdate()
+	if file == jw.currentFile {
+		if err := jw.handleJournalUpdate(); err != nil {
+			jw.logger.Error(fmt.Sprintf("Failed to read journal update: %v", err))
 		}
-	}()
+		return
+	}
+
+	if !journalFilePattern.MatchString(file) {
+		return
+	}
+
+	// Done with the previous journal, the game does not go back to it
+	if jw.currentFile != "" {
+		jw.saveIndex()
+	}
+
+	jw.currentFile = file
+	jw.seek = 0
+	jw.carry = nil
+	if err := jw.handleJournalUpdate(); err != nil {
+		jw.logger.Error(fmt.Sprintf("Failed to read journal update: %v", err))
+	}
+}
+
+// SetCommander sets the commander that events are attributed to until the
+// journal says otherwise. Call before Sync/Start with the last known commander,
+// since the journal only names the commander when the game is loaded.
+func (jw *Watcher) SetCommander(fid string) {
+	jw.commanderFID = fid
 }
 
+// Close stops watching, it is safe to call more than once
 func (jw *Watcher) Close() {
-	if jw.statusDebounce != nil {
-		jw.statusDebounce.Stop()
-	}
-	jw.watcher.Close()
+	jw.closeOnce.Do(func() {
+		close(jw.closed)
+		jw.statusMu.Lock()
+		if jw.statusDebounce != nil {
+			jw.statusDebounce.Stop()
+		}
+		jw.statusMu.Unlock()
+		jw.watcher.Close()
+
+		jw.mu.Lock()
+		jw.saveIndex()
+		jw.mu.Unlock()
+	})
 }
 
 func (jw *Watcher) handleJournalUpdate() error {
@@ -124,35 +258,33 @@ func (jw *Watcher) handleJournalUpdate() error {
 	}
 	defer file.Close()
 
-	pos, err := file.Seek(jw.seek, 0)
+	// Bytes already read but not yet part of a complete line sit in jw.carry,
+	// so we continue reading right after them.
+	readFrom := jw.seek + int64(len(jw.carry))
+	pos, err := file.Seek(readFrom, io.SeekStart)
 	if err != nil {
-		panic(err)
+		return fmt.Errorf("failed to seek to %d in %s: %w", readFrom, jw.currentFile, err)
 	}
-	if pos != jw.seek {
-		panic(fmt.Sprintf("Failed to seek! got to %d aimed for %d", pos, jw.seek))
+	if pos != readFrom {
+		return fmt.Errorf("failed to seek in %s, got to %d aimed for %d", jw.currentFile, pos, readFrom)
 	}
 
-	buf := []byte{}
-	for {
-		b := make([]byte, 50000)
-		n, err := file.Read(b)
-		jw.seek += int64(n)
-		if len(buf) == 0 {
-			buf = b[:n]
-		} else {
-			buf = append(buf, b[:n]...)
-		}
-
-		if err == io.EOF {
-			break
-		}
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", jw.currentFile, err)
 	}
 
-	lines, err := jw.parseLines(buf)
-	if err != nil {
-		panic(fmt.Sprintf("Failed to parse journal data: %v", err))
+	buf := append(jw.carry, data...)
+	complete, rest := splitCompleteLines(buf)
+	from := jw.seek
+	jw.seek += int64(len(complete))
+	jw.carry = slices.Clone(rest)
+	if len(rest) > 0 {
+		jw.logger.Trace(fmt.Sprintf("[handleJournalUpdate] Carrying over %d bytes of incomplete line", len(rest)))
 	}
 
+	lines := jw.parseLines(complete)
+	jw.indexLines(jw.currentFile, from, jw.seek, lines)
 	if len(lines) == 0 {
 		return nil
 	}
@@ -163,19 +295,39 @@ func (jw *Watcher) handleJournalUpdate() error {
 	return nil
 }
 
+// splitCompleteLines splits data after the last newline. Elite may flush a
+// line in several writes, so anything after the last newline is not
+// guaranteed to be a complete event yet.
+func splitCompleteLines(data []byte) (complete, rest []byte) {
+	i := bytes.LastIndexByte(data, '\n')
+	if i < 0 {
+		return nil, data
+	}
+	return data[:i+1], data[i+1:]
+}
+
 type parsedLine struct {
 	Raw       []byte
 	Timestamp time.Time
 	Event     EventType
 }
 
-func (jw *Watcher) parseLines(data []byte) ([]parsedLine, error) {
+// LineError describes a journal line that could not be parsed and was skipped
+type LineError struct {
+	File string `json:"file"`
+	Line string `json:"line"`
+	Err  string `json:"error"`
+}
+
+// Lines that fail to parse are skipped and reported on jw.LineErrors
+func (jw *Watcher) parseLines(data []byte) []parsedLine {
 	rawLines := slice.Split(data, '\n')
 	jw.logger.Trace(fmt.Sprintf("[parseLines] Processing %d lines", len(rawLines)))
 
 	parsed := make([]parsedLine, 0, len(rawLines))
 
 	for i, line := range rawLines {
+		line = bytes.TrimRight(line, "\r")
 		if len(line) == 0 {
 			continue
 		}
@@ -187,8 +339,13 @@ func (jw *Watcher) parseLines(data []byte) ([]parsedLine, error) {
 			Event     EventType `json:"event"`
 		}
 		if err := json.Unmarshal(line, &base); err != nil {
-			jw.logger.Trace(fmt.Sprintf("[parseLines] Line %d: unmarshal error: %v", i, err))
-			return nil, err
+			jw.logger.Warning(fmt.Sprintf("[parseLines] Skipping malformed line %d in %s: %v", i, jw.currentFile, err))
+			jw.LineErrors.Publish(&LineError{
+				File: jw.currentFile,
+				Line: string(line),
+				Err:  err.Error(),
+			})
+			continue
 		}
 
 		jw.logger.Trace(fmt.Sprintf("[parseLines] Line %d: event=%s timestamp=%v", i, base.Event, base.Timestamp))
@@ -200,7 +357,7 @@ func (jw *Watcher) parseLines(data []byte) ([]parsedLine, error) {
 		})
 	}
 
-	return parsed, nil
+	return parsed
 }
 
 func (jw *Watcher) filterSyncBoundary(lines []parsedLine, syncState *models.JournalSync) []parsedLine {
@@ -238,28 +395,66 @@ func hashLine(raw []byte) string {
 
 func (jw *Watcher) publishSyncState(last parsedLine) {
 	jw.SyncState.Publish(models.JournalSync{
-		Timestamp: last.Timestamp,
-		EventHash: hashLine(last.Raw),
+		Timestamp:    last.Timestamp,
+		EventHash:    hashLine(last.Raw),
+		CommanderFID: jw.commanderFID,
 	})
 }
 
+// addBoost keeps the strongest boost, they don't stack
+func (jw *Watcher) addBoost(boost models.FSDBoost) {
+	if jw.boost == nil || jw.boost.RangeMultiplier() < boost.RangeMultiplier() {
+		jw.boost = &boost
+	}
+}
+
 func (jw *Watcher) dispatch(lines []parsedLine) {
 	for _, line := range lines {
 		switch line.Event {
+		case Commander:
+			var event CommanderEvent
+			if err := json.Unmarshal(line.Raw, &event); err == nil {
+				jw.commanderFID = event.FID
+				jw.logger.Trace(fmt.Sprintf("[dispatch] Publishing Commander: %s (%s)", event.Name, event.FID))
+				jw.Commander.Publish(&event)
+			}
+		case LoadGame:
+			var event LoadGameEvent
+			if err := json.Unmarshal(line.Raw, &event); err == nil {
+				jw.commanderFID = event.FID
+				jw.logger.Trace(fmt.Sprintf("[dispatch] Publishing LoadGame: %s (%s)", event.Commander, event.FID))
+				jw.LoadGame.Publish(&event)
+				jw.Commander.Publish(&CommanderEvent{
+					Timestamp: event.Timestamp,
+					Event:     event.Event,
+					FID:       event.FID,
+					Name:      event.Commander,
+				})
+			}
 		case Loadout:
 			var event LoadoutEvent
 			if err := json.Unmarshal(line.Raw, &event); err == nil {
+				event.CommanderFID = jw.commanderFID
 				jw.logger.Trace("[dispatch] Publishing Loadout")
 				jw.Loadout.Publish(&event)
 			}
 		case FSDJump:
 			var event FSDJumpEvent
 			if err := json.Unmarshal(line.Raw, &event); err == nil {
+				event.CommanderFID = jw.commanderFID
+				event.Boost, jw.boost = jw.boost, nil
 				jw.logger.Trace(fmt.Sprintf("[FSD_TIMING] FSDJump event: system=%s, timestamp=%v, fuelLevel=%.2f, fuelUsed=%.2f",
 					event.StarSystem, event.Timestamp, event.FuelLevel, event.FuelUsed))
 				jw.logger.Trace("[dispatch] Publishing FSDJump")
 				jw.FSDJump.Publish(&event)
 			}
+		case CarrierJump:
+			var event CarrierJumpEvent
+			if err := json.Unmarshal(line.Raw, &event); err == nil {
+				event.CommanderFID = jw.commanderFID
+				jw.logger.Trace(fmt.Sprintf("[dispatch] Publishing CarrierJump: %s", event.StarSystem))
+				jw.CarrierJump.Publish(&event)
+			}
 		case FSDTarget:
 			var event FSDTargetEvent
 			if err := json.Unmarshal(line.Raw, &event); err == nil {
@@ -271,6 +466,11 @@ func (jw *Watcher) dispatch(lines []parsedLine) {
 		case Location:
 			var event LocationEvent
 			if err := json.Unmarshal(line.Raw, &event); err == nil {
+				event.CommanderFID = jw.commanderFID
+				if jw.resurrect != nil {
+					event.Died, event.Resurrect = jw.died, jw.resurrect
+					jw.died, jw.resurrect = nil, nil
+				}
 				jw.logger.Trace("[dispatch] Publishing Location")
 				jw.Location.Publish(&event)
 			}
@@ -286,6 +486,113 @@ func (jw *Watcher) dispatch(lines []parsedLine) {
 				jw.logger.Trace(fmt.Sprintf("[dispatch] Publishing StartJump: %s to %s", event.JumpType, starSystem))
 				jw.StartJump.Publish(&event)
 			}
+		case ShipyardSwap:
+			var event ShipyardSwapEvent
+			if err := json.Unmarshal(line.Raw, &event); err == nil {
+				event.CommanderFID = jw.commanderFID
+				jw.logger.Trace(fmt.Sprintf("[dispatch] Publishing ShipyardSwap: %s (%d)", event.ShipType, event.ShipID))
+				jw.ShipyardSwap.Publish(&event)
+			}
+		case ShipyardNew:
+			var event ShipyardNewEvent
+			if err := json.Unmarshal(line.Raw, &event); err == nil {
+				event.CommanderFID = jw.commanderFID
+				jw.logger.Trace(fmt.Sprintf("[dispatch] Publishing ShipyardNew: %s (%d)", event.ShipType, event.NewShipID))
+				jw.ShipyardNew.Publish(&event)
+			}
+		case SellShipOnRebuy:
+			var event SellShipOnRebuyEvent
+			if err := json.Unmarshal(line.Raw, &event); err == nil {
+				event.CommanderFID = jw.commanderFID
+				jw.logger.Trace(fmt.Sprintf("[dispatch] Publishing SellShipOnRebuy: %s (%d)", event.ShipType, event.SellShipID))
+				jw.SellShipOnRebuy.Publish(&event)
+			}
+		case FuelScoop:
+			var event FuelScoopEvent
+			if err := json.Unmarshal(line.Raw, &event); err == nil {
+				event.CommanderFID = jw.commanderFID
+				jw.logger.Trace(fmt.Sprintf("[dispatch] Publishing FuelScoop: %.2ft", event.Scooped))
+				jw.FuelScoop.Publish(&event)
+			}
+		case HullDamage:
+			var event HullDamageEvent
+			if err := json.Unmarshal(line.Raw, &event); err == nil {
+				event.CommanderFID = jw.commanderFID
+				jw.logger.Trace(fmt.Sprintf("[dispatch] Publishing HullDamage: %.2f", event.Health))
+				jw.HullDamage.Publish(&event)
+			}
+		case AfmuRepairs:
+			var event AfmuRepairsEvent
+			if err := json.Unmarshal(line.Raw, &event); err == nil {
+				event.CommanderFID = jw.commanderFID
+				jw.logger.Trace(fmt.Sprintf("[dispatch] Publishing AfmuRepairs: %s %.2f", event.Module, event.Health))
+				jw.AfmuRepairs.Publish(&event)
+			}
+		case Repair:
+			var event RepairEvent
+			if err := json.Unmarshal(line.Raw, &event); err == nil {
+				event.CommanderFID = jw.commanderFID
+				jw.logger.Trace(fmt.Sprintf("[dispatch] Publishing Repair: %v", event.Repaired()))
+				jw.Repair.Publish(&event)
+			}
+		case JetConeBoost:
+			var event JetConeBoostEvent
+			if err := json.Unmarshal(line.Raw, &event); err == nil {
+				if boost, ok := event.FSDBoost(); ok {
+					jw.addBoost(boost)
+				}
+				jw.logger.Trace(fmt.Sprintf("[dispatch] Publishing JetConeBoost: %.1f", event.BoostValue))
+				jw.JetConeBoost.Publish(&event)
+			}
+		case Synthesis:
+			var event SynthesisEvent
+			if err := json.Unmarshal(line.Raw, &event); err == nil {
+				boost, ok := event.FSDBoost()
+				if !ok {
+					continue
+				}
+				jw.addBoost(boost)
+				jw.logger.Trace(fmt.Sprintf("[dispatch] Publishing Synthesis: %s", event.Name))
+				jw.Synthesis.Publish(&event)
+			}
+		case Died:
+			var event DiedEvent
+			if err := json.Unmarshal(line.Raw, &event); err == nil {
+				jw.logger.Trace("[dispatch] Died, waiting for respawn")
+				jw.died, jw.resurrect = &event, nil
+			}
+		case Resurrect:
+			var event ResurrectEvent
+			if err := json.Unmarshal(line.Raw, &event); err == nil && jw.died != nil {
+				jw.logger.Trace(fmt.Sprintf("[dispatch] Resurrect: %s", event.Option))
+				jw.resurrect = &event
+			}
+		case NavRoute, NavRouteClear:
+			jw.handleNavRoute(line.Raw)
+		case Scan:
+			var event ScanEvent
+			if err := json.Unmarshal(line.Raw, &event); err == nil {
+				jw.logger.Trace(fmt.Sprintf("[dispatch] Publishing Scan: %s", event.BodyName))
+				jw.Scan.Publish(&event)
+			}
+		case FSSDiscoveryScan:
+			var event FSSDiscoveryScanEvent
+			if err := json.Unmarshal(line.Raw, &event); err == nil {
+				jw.logger.Trace(fmt.Sprintf("[dispatch] Publishing FSSDiscoveryScan: %s (%d bodies)", event.SystemName, event.BodyCount))
+				jw.FSSDiscoveryScan.Publish(&event)
+			}
+		case FSSAllBodiesFound:
+			var event FSSAllBodiesFoundEvent
+			if err := json.Unmarshal(line.Raw, &event); err == nil {
+				jw.logger.Trace(fmt.Sprintf("[dispatch] Publishing FSSAllBodiesFound: %s", event.SystemName))
+				jw.FSSAllBodiesFound.Publish(&event)
+			}
+		case SAASignalsFound:
+			var event SAASignalsFoundEvent
+			if err := json.Unmarshal(line.Raw, &event); err == nil {
+				jw.logger.Trace(fmt.Sprintf("[dispatch] Publishing SAASignalsFound: %s", event.BodyName))
+				jw.SAASignalsFound.Publish(&event)
+			}
 		}
 	}
 }
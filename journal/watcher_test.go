@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -74,6 +76,19 @@ func appendJournal(t *testing.T, dir, filename, content string) {
 	}
 }
 
+func writeRaw(t *testing.T, dir, filename, content string) {
+	t.Helper()
+	filePath := filepath.Join(dir, filename)
+	f, err := os.OpenFile(filePath, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0644)
+	if err != nil {
+		t.Fatalf("Failed to open journal file %s: %v", filename, err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString(content); err != nil {
+		t.Fatalf("Failed to write to journal file %s: %v", filename, err)
+	}
+}
+
 func collectTargetEvents(ch chan *FSDTargetEvent, expected int, timeout time.Duration) []*FSDTargetEvent {
 	events := make([]*FSDTargetEvent, 0, expected)
 	deadline := time.After(timeout)
@@ -297,8 +312,9 @@ func (s *SyncTestSuite) TestExactTimestampDedup() {
 	syncCh := s.watcher.SyncState.Subscribe()
 
 	s.Require().NoError(s.watcher.Sync(models.JournalSync{
-		Timestamp: time.Date(2024, 12, 19, 10, 5, 0, 0, time.UTC),
-		EventHash: hashLine([]byte(exactEvent)),
+		Timestamp:    time.Date(2024, 12, 19, 10, 5, 0, 0, time.UTC),
+		EventHash:    hashLine([]byte(exactEvent)),
+		CommanderFID: "F1",
 	}))
 
 	events := collectTargetEvents(targetCh, 3, time.Second)
@@ -311,6 +327,67 @@ func (s *SyncTestSuite) TestExactTimestampDedup() {
 	s.Require().Len(syncUpdates, 1)
 	s.Equal(hashLine([]byte(laterEvent)), syncUpdates[0].EventHash)
 	s.Equal(time.Date(2024, 12, 19, 10, 10, 0, 0, time.UTC), syncUpdates[0].Timestamp)
+	s.Equal("F1", syncUpdates[0].CommanderFID, "still the commander of the sync position")
+}
+
+func (s *SyncTestSuite) TestIndexSkipsProcessedFiles() {
+	writeJournal(s.T(), s.tmpDir, "Journal.2024-12-19T100000.01.log",
+		`{"timestamp":"2024-12-19T10:00:00Z","event":"Fileheader","part":1}`+"\n"+
+			fsdTargetEvent("2024-12-19T10:05:00Z", "Early", 1))
+	writeJournal(s.T(), s.tmpDir, "Journal.2024-12-19T100000.02.log",
+		fsdTargetEvent("2024-12-19T10:15:00Z", "Middle", 2))
+
+	index := &models.JournalIndex{Dir: s.tmpDir, Files: map[string]*models.JournalFileInfo{
+		"Journal.2024-12-01T100000.01.log": {},
+	}}
+
+	saves := 0
+	save := func(*models.JournalIndex) error {
+		saves++
+		return nil
+	}
+
+	s.createWatcher()
+	s.watcher.SetIndex(index, save)
+	s.Require().NoError(s.watcher.Sync(models.JournalSync{
+		Timestamp: time.Date(2024, 12, 19, 9, 0, 0, 0, time.UTC),
+	}))
+	s.Equal(1, saves, "saved after the sync")
+	s.watcher.Close()
+
+	s.NotContains(index.Files, "Journal.2024-12-01T100000.01.log")
+	s.Require().Contains(index.Files, "Journal.2024-12-19T100000.01.log")
+	first := index.Files["Journal.2024-12-19T100000.01.log"]
+	s.Equal(1, first.Part)
+	s.Equal(time.Date(2024, 12, 19, 10, 5, 0, 0, time.UTC), first.LastEvent)
+
+	// A later sync only reads the file that grew
+	appendJournal(s.T(), s.tmpDir, "Journal.2024-12-19T100000.02.log",
+		fsdTargetEvent("2024-12-19T10:25:00Z", "Late", 3))
+
+	logger := s.createWatcherWithRecorder()
+	s.watcher.SetIndex(index, save)
+	ch := s.watcher.FSDTarget.Subscribe()
+	s.Require().NoError(s.watcher.Sync(models.JournalSync{
+		Timestamp: time.Date(2024, 12, 19, 10, 15, 0, 0, time.UTC),
+		EventHash: index.Files["Journal.2024-12-19T100000.02.log"].LastLineHash,
+	}))
+
+	events := collectTargetEvents(ch, 1, time.Second)
+	s.Require().Len(events, 1)
+	s.Equal("Late", events[0].Name)
+
+	skipped := 0
+	for _, msg := range logger.Messages {
+		if strings.Contains(msg, "Skipping indexed journal") {
+			skipped++
+		}
+	}
+	s.Equal(1, skipped)
+
+	info, err := os.Stat(filepath.Join(s.tmpDir, "Journal.2024-12-19T100000.02.log"))
+	s.Require().NoError(err)
+	s.Equal(info.Size(), index.Files["Journal.2024-12-19T100000.02.log"].Offset, "read on from the indexed offset")
 }
 
 func TestSyncTestSuite(t *testing.T) {
@@ -390,6 +467,175 @@ func (s *LiveTestSuite) TestMultipleEventTypes() {
 	s.Equal("Sol", jumps[0].StarSystem)
 }
 
+func (s *LiveTestSuite) TestPartialLineIsCarriedOver() {
+	ch := s.watcher.FSDTarget.Subscribe()
+
+	line := fsdTargetEvent("2024-12-19T10:05:00Z", "Sol", 1)
+	half := len(line) / 2
+
+	writeRaw(s.T(), s.tmpDir, "Journal.2024-12-19T100000.01.log", line[:half])
+
+	events := collectTargetEvents(ch, 0, 100*time.Millisecond)
+	s.Len(events, 0)
+
+	writeRaw(s.T(), s.tmpDir, "Journal.2024-12-19T100000.01.log", line[half:]+"\n")
+
+	events = collectTargetEvents(ch, 1, time.Second)
+	s.Require().Len(events, 1)
+	s.Equal("Sol", events[0].Name)
+}
+
+func (s *LiveTestSuite) TestMalformedLineIsSkippedAndReported() {
+	targetCh := s.watcher.FSDTarget.Subscribe()
+	errCh := s.watcher.LineErrors.Subscribe()
+
+	writeJournal(s.T(), s.tmpDir, "Journal.2024-12-19T100000.01.log",
+		fsdTargetEvent("2024-12-19T10:05:00Z", "Before", 1)+"\n"+
+			`{"timestamp":"2024-12-19T10:06:00Z","event":"FSDTa`+"\x00"+`rget"`+"\n"+
+			fsdTargetEvent("2024-12-19T10:07:00Z", "After", 2))
+
+	events := collectTargetEvents(targetCh, 2, time.Second)
+	s.Require().Len(events, 2)
+	s.Equal("Before", events[0].Name)
+	s.Equal("After", events[1].Name)
+
+	select {
+	case lineErr := <-errCh:
+		s.Equal("Journal.2024-12-19T100000.01.log", lineErr.File)
+		s.NotEmpty(lineErr.Err)
+	case <-time.After(time.Second):
+		s.Fail("Timeout waiting for line error")
+	}
+}
+
+func (s *LiveTestSuite) TestNavRouteIsReadAndCleared() {
+	ch := s.watcher.NavRoute.Subscribe()
+
+	navRoute := `{"timestamp":"2024-12-19T10:05:00Z","event":"NavRoute","Route":[` +
+		`{"StarSystem":"Sol","SystemAddress":1,"StarPos":[0,0,0],"StarClass":"G"},` +
+		`{"StarSystem":"Luhman 16","SystemAddress":2,"StarPos":[3,4,0],"StarClass":"L"},` +
+		`{"StarSystem":"HD 1","SystemAddress":3,"StarPos":[5,4,0],"StarClass":"AeBe"},` +
+		`{"StarSystem":"HD 2","SystemAddress":4,"StarPos":[6,4,0],"StarClass":"K_OrangeGiant"}]}`
+	s.Require().NoError(os.WriteFile(filepath.Join(s.tmpDir, "NavRoute.json"), []byte(navRoute), 0644))
+
+	writeJournal(s.T(), s.tmpDir, "Journal.2024-12-19T100000.01.log",
+		`{"timestamp":"2024-12-19T10:05:00Z","event":"NavRoute"}`)
+
+	select {
+	case event := <-ch:
+		s.Require().Len(event.Route, 4)
+		s.Equal("Luhman 16", event.Route[1].StarSystem)
+		s.True(event.Route[0].Scoopable())
+		s.False(event.Route[1].Scoopable())
+		s.False(event.Route[2].Scoopable(), "Herbig Ae/Be stars are not scoopable")
+		s.True(event.Route[3].Scoopable())
+	case <-time.After(time.Second):
+		s.Fail("Timeout waiting for NavRoute")
+	}
+
+	appendJournal(s.T(), s.tmpDir, "Journal.2024-12-19T100000.01.log",
+		`{"timestamp":"2024-12-19T10:06:00Z","event":"NavRouteClear"}`)
+
+	select {
+	case event := <-ch:
+		s.Equal(NavRouteClear, event.Event)
+		s.Empty(event.Route)
+	case <-time.After(time.Second):
+		s.Fail("Timeout waiting for NavRouteClear")
+	}
+}
+
+func (s *LiveTestSuite) TestStatusSnapshotIsDecoded() {
+	ch := s.watcher.Status.Subscribe()
+
+	status := `{"timestamp":"2024-12-19T10:05:00Z","event":"Status","Flags":` +
+		strconv.Itoa(int(FlagInMainShip|FlagScoopingFuel|FlagOverHeating|FlagLowFuel)) +
+		`,"Flags2":0,"Pips":[4,8,0],"FireGroup":0,"GuiFocus":0,"Fuel":{"FuelMain":4.5,"FuelReservoir":0.4},"Cargo":3.0,"Heat":1.2,` +
+		`"LegalState":"Clean","Destination":{"System":10477373803,"Body":0,"Name":"Sol"}}`
+	s.Require().NoError(os.WriteFile(filepath.Join(s.tmpDir, "Status.json"), []byte(status), 0644))
+
+	select {
+	case event := <-ch:
+		s.True(event.Has(FlagScoopingFuel))
+		s.True(event.Has(FlagOverHeating))
+		s.True(event.Has(FlagLowFuel))
+		s.False(event.Has(FlagIsInDanger))
+		s.False(event.Has2(Flag2OnFoot))
+		s.Equal(3.0, *event.Cargo)
+		s.Require().NotNil(event.Heat)
+		s.Equal(1.2, *event.Heat)
+		s.Equal("Clean", *event.LegalState)
+		s.Equal(&[3]int{4, 8, 0}, event.Pips)
+		s.Require().NotNil(event.Destination)
+		s.Equal("Sol", event.Destination.Name)
+	case <-time.After(time.Second):
+		s.Fail("Timeout waiting for Status")
+	}
+}
+
+func (s *LiveTestSuite) TestFallsBackToPollingWithoutEvents() {
+	// A fresh watcher that never gets fsnotify events, like on some network
+	// mounts
+	s.watcher.Close()
+	var err error
+	s.watcher, err = NewWatcher(s.tmpDir, &TestLogger{})
+	s.Require().NoError(err)
+	s.Require().NoError(s.watcher.watcher.Remove(s.tmpDir))
+	s.watcher.SetPollInterval(20 * time.Millisecond)
+	ch := s.watcher.FSDTarget.Subscribe()
+	s.watcher.Start()
+
+	writeJournal(s.T(), s.tmpDir, "Journal.2024-12-19T100000.01.log", "")
+	for i := range 5 {
+		appendJournal(s.T(), s.tmpDir, "Journal.2024-12-19T100000.01.log",
+			fsdTargetEvent(fmt.Sprintf("2024-12-19T10:0%d:00Z", i), fmt.Sprintf("System %d", i), i))
+		time.Sleep(30 * time.Millisecond)
+	}
+
+	events := collectTargetEvents(ch, 5, time.Second)
+	s.True(s.watcher.Polling())
+	s.Require().Len(events, 5)
+	s.Equal("System 0", events[0].Name)
+	s.Equal("System 4", events[4].Name)
+}
+
+func (s *LiveTestSuite) TestLiveJournalsAreIndexed() {
+	var saved atomic.Int32
+	index := &models.JournalIndex{Dir: s.tmpDir, Files: map[string]*models.JournalFileInfo{}}
+	s.watcher.SetIndex(index, func(*models.JournalIndex) error {
+		saved.Add(1)
+		return nil
+	})
+	ch := s.watcher.FSDTarget.Subscribe()
+
+	writeJournal(s.T(), s.tmpDir, "Journal.2024-12-19T100000.01.log", fsdTargetEvent("2024-12-19T10:00:00Z", "First", 1))
+	s.Require().Len(collectTargetEvents(ch, 1, time.Second), 1)
+	appendJournal(s.T(), s.tmpDir, "Journal.2024-12-19T100000.01.log", fsdTargetEvent("2024-12-19T10:05:00Z", "Second", 2))
+	s.Require().Len(collectTargetEvents(ch, 1, time.Second), 1)
+	s.Equal(int32(0), saved.Load())
+
+	writeJournal(s.T(), s.tmpDir, "Journal.2024-12-19T110000.01.log", fsdTargetEvent("2024-12-19T11:00:00Z", "Third", 3))
+	s.Require().Len(collectTargetEvents(ch, 1, time.Second), 1)
+	s.Equal(int32(1), saved.Load(), "saved when moving on to the next journal")
+
+	s.watcher.Close()
+	s.Equal(int32(2), saved.Load(), "saved on close")
+
+	info, err := os.Stat(filepath.Join(s.tmpDir, "Journal.2024-12-19T100000.01.log"))
+	s.Require().NoError(err)
+	first := index.Files["Journal.2024-12-19T100000.01.log"]
+	s.Require().NotNil(first)
+	s.Equal(info.Size(), first.Offset)
+	s.Equal(time.Date(2024, 12, 19, 10, 0, 0, 0, time.UTC), first.FirstEvent)
+	s.Equal(time.Date(2024, 12, 19, 10, 5, 0, 0, time.UTC), first.LastEvent)
+	s.Contains(index.Files, "Journal.2024-12-19T110000.01.log")
+}
+
+func (s *LiveTestSuite) TestCloseTwice() {
+	s.watcher.Close()
+	s.NotPanics(s.watcher.Close)
+}
+
 func TestLiveTestSuite(t *testing.T) {
 	suite.Run(t, new(LiveTestSuite))
 }
@@ -3,6 +3,7 @@ package main
 import (
 	"ed-expedition/lib/form"
 	"ed-expedition/models"
+	"ed-expedition/services"
 	"embed"
 	"flag"
 	"os"
@@ -50,6 +51,8 @@ func main() {
 			AllGalaxyStatus,
 			models.AllFSDBoost,
 			form.AllInputType,
+			services.AllHistoryDiffKind,
+			services.AllHazardKind,
 		},
 	})
 
@@ -4,25 +4,190 @@ import (
 	"ed-expedition/database"
 	"ed-expedition/migrations"
 	"os"
+	"slices"
 	"time"
 )
 
 type AppState struct {
+	// Always reflects the current commander
 	LastKnownLoadout  *Loadout  `json:"last_known_loadout,omitempty"`
 	LastKnownLocation *Location `json:"last_known_location,omitempty"`
 
+	CurrentCommander *string                    `json:"current_commander,omitempty"`
+	Commanders       map[string]*CommanderState `json:"commanders,omitempty"`
+
 	GalaxyDownloadedAt *time.Time `json:"galaxy_downloaded_at,omitempty"`
 
+	// Position in the journal directory, across all commanders. The position
+	// of each commander is in their CommanderState.
+	JournalSync *JournalSync `json:"journal_sync,omitempty"`
+}
+
+// CommanderState is the state of one commander, keyed by FID in
+// AppState.Commanders
+type CommanderState struct {
+	Name              string    `json:"name"`
+	LastKnownLoadout  *Loadout  `json:"last_known_loadout,omitempty"`
+	LastKnownLocation *Location `json:"last_known_location,omitempty"`
+	// Last journal event processed while this commander was logged in
 	JournalSync *JournalSync `json:"journal_sync,omitempty"`
+
+	// Ship the commander is flying, LastKnownLoadout is its loadout if known
+	ActiveShip *ActiveShip `json:"active_ship,omitempty"`
+	// Last known loadout of every ship the commander owns, keyed by ShipID
+	Loadouts map[int]*Loadout `json:"loadouts,omitempty"`
+}
+
+type ActiveShip struct {
+	ShipID int `json:"ship_id"`
+	// When the commander got into the ship
+	Since time.Time `json:"since"`
+}
+
+// SwitchShip makes shipID the active ship, unless a later switch is already
+// known. Returns false if nothing changed.
+func (commander *CommanderState) SwitchShip(shipID int, timestamp time.Time) bool {
+	if commander.ActiveShip != nil && commander.ActiveShip.Since.After(timestamp) {
+		return false
+	}
+	if commander.ActiveShip != nil && commander.ActiveShip.ShipID == shipID {
+		return false
+	}
+
+	commander.ActiveShip = &ActiveShip{ShipID: shipID, Since: timestamp}
+	commander.LastKnownLoadout = commander.Loadouts[shipID]
+	return true
+}
+
+// SetLoadout records the loadout of loadout.ShipID. The game only writes a
+// loadout for the ship the commander is in, so a loadout newer than the last
+// switch also makes it the active ship. Returns false if the loadout is older
+// than what we have.
+func (commander *CommanderState) SetLoadout(loadout *Loadout) bool {
+	if known, ok := commander.Loadouts[loadout.ShipID]; ok && known.Timestamp.After(loadout.Timestamp) {
+		return false
+	}
+	if commander.Loadouts == nil {
+		commander.Loadouts = map[int]*Loadout{}
+	}
+	commander.Loadouts[loadout.ShipID] = loadout
+
+	active := commander.ActiveShip
+	if active == nil || active.ShipID == loadout.ShipID || active.Since.Before(loadout.Timestamp) {
+		if active == nil || active.ShipID != loadout.ShipID {
+			commander.ActiveShip = &ActiveShip{ShipID: loadout.ShipID, Since: loadout.Timestamp}
+		}
+		commander.LastKnownLoadout = loadout
+	}
+	return true
+}
+
+// RemoveShip forgets a ship the commander no longer owns
+func (commander *CommanderState) RemoveShip(shipID int) bool {
+	if _, ok := commander.Loadouts[shipID]; !ok {
+		return false
+	}
+	delete(commander.Loadouts, shipID)
+	return true
+}
+
+// Commander returns the state of the commander with the given FID, creating it
+// if needed. Returns nil for an empty FID.
+func (state *AppState) Commander(fid string) *CommanderState {
+	if fid == "" {
+		return nil
+	}
+	if state.Commanders == nil {
+		state.Commanders = map[string]*CommanderState{}
+	}
+	commander, ok := state.Commanders[fid]
+	if !ok {
+		commander = &CommanderState{}
+		state.Commanders[fid] = commander
+	}
+	return commander
+}
+
+// IsCurrentCommander reports whether state for fid belongs in the top level
+// LastKnown* fields. Events without a known commander are attributed to the
+// current one.
+func (state *AppState) IsCurrentCommander(fid string) bool {
+	return fid == "" || state.CurrentCommander == nil || *state.CurrentCommander == fid
+}
+
+// SwitchCommander makes fid the current commander and swaps in its loadout and
+// location. State recorded before commanders were tracked is adopted by the
+// first commander we see.
+func (state *AppState) SwitchCommander(fid, name string) {
+	if fid == "" {
+		return
+	}
+
+	adoptLegacy := state.CurrentCommander == nil && len(state.Commanders) == 0
+	commander := state.Commander(fid)
+	if name != "" {
+		commander.Name = name
+	}
+	if adoptLegacy {
+		commander.LastKnownLoadout = state.LastKnownLoadout
+		commander.LastKnownLocation = state.LastKnownLocation
+		commander.JournalSync = state.JournalSync
+	}
+
+	state.CurrentCommander = &fid
+	state.LastKnownLoadout = commander.LastKnownLoadout
+	state.LastKnownLocation = commander.LastKnownLocation
+}
+
+// ShipLoadout returns the last known loadout of one of the current commander's
+// ships, nil if unknown.
+func (state *AppState) ShipLoadout(shipID int) *Loadout {
+	if state.CurrentCommander != nil {
+		if commander, ok := state.Commanders[*state.CurrentCommander]; ok {
+			if loadout, ok := commander.Loadouts[shipID]; ok {
+				return loadout
+			}
+		}
+	}
+	if state.LastKnownLoadout != nil && state.LastKnownLoadout.ShipID == shipID {
+		return state.LastKnownLoadout
+	}
+	return nil
+}
+
+// ShipLoadouts returns the last known loadouts of all the current commander's
+// ships
+func (state *AppState) ShipLoadouts() []*Loadout {
+	loadouts := []*Loadout{}
+	if state.CurrentCommander != nil {
+		if commander, ok := state.Commanders[*state.CurrentCommander]; ok {
+			for _, loadout := range commander.Loadouts {
+				loadouts = append(loadouts, loadout)
+			}
+		}
+	}
+	if len(loadouts) == 0 && state.LastKnownLoadout != nil {
+		loadouts = append(loadouts, state.LastKnownLoadout)
+	}
+	slices.SortFunc(loadouts, func(a, b *Loadout) int { return a.ShipID - b.ShipID })
+	return loadouts
 }
 
 type JournalSync struct {
 	Timestamp time.Time `json:"timestamp"`
 	EventHash string    `json:"event_hash"`
+	// Commander the journal was written for at this position, missing on
+	// positions saved before commanders were tracked
+	CommanderFID string `json:"commander_fid,omitempty"`
 }
 
 type Loadout struct {
-	Timestamp    time.Time    `json:"timestamp"`
+	Timestamp time.Time `json:"timestamp"`
+	// Missing on loadouts saved before ships were tracked
+	ShipID       int          `json:"ship_id"`
+	Ship         string       `json:"ship,omitempty"`
+	ShipName     string       `json:"ship_name,omitempty"`
+	ShipIdent    string       `json:"ship_ident,omitempty"`
 	UnladenMass  float64      `json:"unladen_mass"`
 	FuelCapacity FuelCapacity `json:"fuel_capacity"`
 	FSD          struct {
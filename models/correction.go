@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// CorrectionKind is the kind of manual change made to an expedition
+type CorrectionKind string
+
+const (
+	CorrectionSetPosition   CorrectionKind = "set_position"
+	CorrectionInsertSkipped CorrectionKind = "insert_skipped"
+	CorrectionDeleteEntry   CorrectionKind = "delete_entry"
+	CorrectionMarkEntry     CorrectionKind = "mark_entry"
+)
+
+// Correction records a manual change to the jump history or the position on
+// the baked route
+type Correction struct {
+	Timestamp time.Time      `json:"timestamp"`
+	Kind      CorrectionKind `json:"kind" ts_type:"'set_position'|'insert_skipped'|'delete_entry'|'mark_entry'"`
+
+	PrevBakedIndex int `json:"prev_baked_index"`
+	BakedIndex     int `json:"baked_index"`
+
+	// Index in the jump history of the entry deleted or marked
+	HistoryIndex *int `json:"history_index,omitempty"`
+	// The entry as it was before the change
+	Entry *JumpHistoryEntry `json:"entry,omitempty"`
+	// Synthetic entries inserted
+	Inserted int `json:"inserted,omitempty"`
+
+	Note string `json:"note,omitempty"`
+}
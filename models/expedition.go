@@ -13,6 +13,7 @@ type ExpeditionStatus string
 const (
 	StatusPlanned   ExpeditionStatus = "planned"
 	StatusActive    ExpeditionStatus = "active"
+	StatusPaused    ExpeditionStatus = "paused"
 	StatusCompleted ExpeditionStatus = "completed"
 	StatusEnded     ExpeditionStatus = "ended"
 )
@@ -23,11 +24,21 @@ type Expedition struct {
 	Name        string           `json:"name"`
 	CreatedAt   time.Time        `json:"created_at"`
 	LastUpdated time.Time        `json:"last_updated"`
-	Status      ExpeditionStatus `json:"status" ts_type:"'planned'|'active'|'completed'|'ended'"`
+	Status      ExpeditionStatus `json:"status" ts_type:"'planned'|'active'|'paused'|'completed'|'ended'"`
+
+	// FID of the commander flying the expedition, set when started
+	CommanderFID string `json:"commander_fid,omitempty"`
 
 	StartedOn time.Time `json:"started_on,omitempty"`
 	EndedOn   time.Time `json:"ended_on,omitempty"`
 
+	// Breaks taken, jumps made while paused are not part of the expedition
+	Pauses []Pause `json:"pauses,omitempty"`
+
+	// Count jumps of the fleet carrier the commander is docked on as progress
+	// along the route
+	CountCarrierJumps bool `json:"count_carrier_jumps,omitempty"`
+
 	// Start point (can be mid-route)
 	Start *RoutePosition `json:"start,omitempty"`
 
@@ -41,13 +52,71 @@ type Expedition struct {
 	CurrentBakedIndex  int     `json:"current_baked_index"`
 	BakedLoopBackIndex *int    `json:"baked_loop_back_index,omitempty"`
 
+	// Complete a looping expedition after this many laps, 0 loops forever
+	MaxLaps int `json:"max_laps,omitempty"`
+	// Laps around a looping baked route, the last one is in progress until
+	// the expedition is completed
+	Laps []Lap `json:"laps,omitempty"`
+
 	JumpHistory []JumpHistoryEntry `json:"jump_history"`
+
+	// Cached when the expedition is completed or ended
+	Stats *ExpeditionStats `json:"stats,omitempty"`
+
+	// Manual changes to the jump history and position, oldest first
+	Corrections []Correction `json:"corrections,omitempty"`
+
+	// Alternative links taken, the baked route was re-baked along each
+	Branches []BranchTaken `json:"branches,omitempty"`
 }
 
 func (e *Expedition) IsEditable() bool {
 	return e.Status == StatusPlanned
 }
 
+// Lap is one time around a looping baked route. The first lap includes the
+// way from the start of the route to the loop.
+type Lap struct {
+	Number int       `json:"number"`
+	Start  time.Time `json:"start"`
+	// Zero while the lap is in progress
+	End time.Time `json:"end,omitempty"`
+	// Computed when the lap is done
+	Stats *ExpeditionStats `json:"stats,omitempty"`
+}
+
+// CompletedLaps is the number of laps done around a looping baked route
+func (e *Expedition) CompletedLaps() int {
+	count := 0
+	for _, lap := range e.Laps {
+		if !lap.End.IsZero() {
+			count++
+		}
+	}
+	return count
+}
+
+// LapsDone reports whether a looping expedition made the laps it was set to
+func (e *Expedition) LapsDone() bool {
+	return e.MaxLaps > 0 && e.CompletedLaps() >= e.MaxLaps
+}
+
+// Pause is a break from the expedition, To is zero while still paused
+type Pause struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to,omitempty"`
+}
+
+// IsPausedAt reports whether the expedition was paused at t
+func (e *Expedition) IsPausedAt(t time.Time) bool {
+	for _, pause := range e.Pauses {
+		if !t.Before(pause.From) && (pause.To.IsZero() || t.Before(pause.To)) {
+			return true
+		}
+	}
+	return false
+}
+
 type RoutePosition struct {
 	RouteID   string `json:"route_id"`
 	JumpIndex int    `json:"jump_index"`
@@ -65,6 +134,17 @@ type Link struct {
 	ID   string        `json:"id"`
 	From RoutePosition `json:"from"`
 	To   RoutePosition `json:"to"`
+	// An optional branch, the commander may take it instead of going on the
+	// default way. Only default links are followed when baking the route.
+	Alternative bool `json:"alternative,omitempty"`
+}
+
+// BranchTaken records the commander jumping into an alternative link
+type BranchTaken struct {
+	Timestamp time.Time `json:"timestamp"`
+	LinkID    string    `json:"link_id"`
+	// Index in the baked route of the system the branch leaves from
+	BakedIndex int `json:"baked_index"`
 }
 
 // JumpHistoryEntry records a single jump taken during expedition
@@ -78,8 +158,105 @@ type JumpHistoryEntry struct {
 	FuelUsed  float64 `json:"fuel_used"`
 	FuelLevel float64 `json:"fuel_in_tank"`
 
+	// Fuel scooped in the system, nil if the commander didn't scoop
+	FuelScoop *FuelScoopRecord `json:"fuel_scoop,omitempty"`
+
+	// Latest known health of the ship while in the system, for the trend over
+	// the expedition
+	Health *ShipHealth `json:"health,omitempty"`
+
+	// Boost the commander actually jumped with, nil without one
+	FSDBoost *FSDBoost `json:"fsd_boost,omitempty"`
+	// Made docked on a fleet carrier, the ship used no fuel
+	Carrier bool `json:"carrier,omitempty"`
+
 	Expected  bool `json:"expected"`
 	Synthetic bool `json:"synthetic"`
+	// Made in a taxi or as multicrew, or a respawn after death. Never matched
+	// against the route
+	OffExpedition bool `json:"off_expedition,omitempty"`
+	// Set when the commander died, the entry is where they respawned
+	Death *DeathRecord `json:"death,omitempty"`
+	// Written when the expedition was resumed with the commander on the route,
+	// not a jump
+	Resumed bool `json:"resumed,omitempty"`
+	// Marked by the commander as not part of the expedition, left out of the
+	// statistics
+	Erroneous bool `json:"erroneous,omitempty"`
+
+	Exploration *SystemExploration `json:"exploration,omitempty"`
+}
+
+// FuelScoopRecord adds up all scooping done in the system of a jump
+type FuelScoopRecord struct {
+	Scooped float64 `json:"scooped"`
+	// Seconds spent scooping, only known while the app is running
+	Duration  float64 `json:"duration"`
+	StartFuel float64 `json:"start_fuel"`
+	EndFuel   float64 `json:"end_fuel"`
+}
+
+// ShipHealth is the integrity of the hull and FSD from 0 to 1, nil if unknown
+type ShipHealth struct {
+	Hull *float64 `json:"hull,omitempty"`
+	FSD  *float64 `json:"fsd,omitempty"`
+}
+
+func (health *ShipHealth) Clone() *ShipHealth {
+	clone := &ShipHealth{}
+	if health.Hull != nil {
+		hull := *health.Hull
+		clone.Hull = &hull
+	}
+	if health.FSD != nil {
+		fsd := *health.FSD
+		clone.FSD = &fsd
+	}
+	return clone
+}
+
+type DeathRecord struct {
+	DiedAt time.Time `json:"died_at"`
+	// Last system on record before dying
+	SystemName string `json:"system_name"`
+	SystemID   int64  `json:"system_id"`
+	KilledBy   string `json:"killed_by,omitempty"`
+	// How the commander came back: rebuy, recover or handin
+	Resurrect string `json:"resurrect,omitempty"`
+}
+
+// SystemExploration records what was scanned in the system of a jump
+type SystemExploration struct {
+	// From the FSS discovery scan (honk), 0 until the system has been honked
+	BodyCount      int  `json:"body_count"`
+	NonBodyCount   int  `json:"non_body_count"`
+	Honked         bool `json:"honked"`
+	AllBodiesFound bool `json:"all_bodies_found"`
+
+	ScannedBodies []ScannedBody `json:"scanned_bodies"`
+	MappedBodies  []MappedBody  `json:"mapped_bodies"`
+}
+
+type ScannedBody struct {
+	BodyID   int    `json:"body_id"`
+	BodyName string `json:"body_name"`
+	// StarType for stars, PlanetClass for planets and moons
+	Type           string `json:"type"`
+	TerraformState string `json:"terraform_state,omitempty"`
+	Landable       bool   `json:"landable"`
+	WasDiscovered  bool   `json:"was_discovered"`
+	WasMapped      bool   `json:"was_mapped"`
+}
+
+type MappedBody struct {
+	BodyID   int          `json:"body_id"`
+	BodyName string       `json:"body_name"`
+	Signals  []BodySignal `json:"signals"`
+}
+
+type BodySignal struct {
+	Type  string `json:"type"`
+	Count int    `json:"count"`
 }
 
 func (expedition *Expedition) LoadRoutes() ([]*Route, error) {
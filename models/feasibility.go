@@ -0,0 +1,78 @@
+package models
+
+type FeasibilityIssueKind string
+
+const (
+	// A jump is longer than the ship's range with the planned boost
+	FeasibilityOutOfRange FeasibilityIssueKind = "out_of_range"
+	// The tank runs dry before the next scoopable star
+	FeasibilityOutOfFuel FeasibilityIssueKind = "out_of_fuel"
+)
+
+type FeasibilitySeverity string
+
+const (
+	FeasibilityWarning FeasibilitySeverity = "warning"
+	// The route can not be flown as planned
+	FeasibilityError FeasibilitySeverity = "error"
+)
+
+// FeasibilityIssue is a problem with a stretch of the baked route. For a jump
+// FromIndex and ToIndex are the systems on either side, for fuel they are the
+// last scoopable star and where the tank runs dry.
+type FeasibilityIssue struct {
+	Kind       FeasibilityIssueKind `json:"kind" ts_type:"'out_of_range'|'out_of_fuel'"`
+	Severity   FeasibilitySeverity  `json:"severity" ts_type:"'warning'|'error'"`
+	FromIndex  int                  `json:"from_index"`
+	ToIndex    int                  `json:"to_index"`
+	SystemName string               `json:"system_name"`
+	// Light years, of the jump or the whole stretch
+	Distance float64 `json:"distance"`
+	// Jump range with the planned boost, for jumps
+	Range float64 `json:"range,omitempty"`
+	// Smallest boost that makes the jump, for jumps
+	SuggestedBoost *FSDBoost `json:"suggested_boost,omitempty"`
+	Message        string    `json:"message"`
+}
+
+// InjectionCount is a number of FSD injections by grade
+type InjectionCount struct {
+	Basic    int `json:"basic"`
+	Standard int `json:"standard"`
+	Premium  int `json:"premium"`
+}
+
+func (c *InjectionCount) Add(boost FSDBoost) {
+	switch boost {
+	case FSDBoostInjectionBasic:
+		c.Basic++
+	case FSDBoostInjectionStandard:
+		c.Standard++
+	case FSDBoostInjectionPremium:
+		c.Premium++
+	}
+}
+
+// FeasibilityReport is the result of checking a baked route against a ship
+// before starting the expedition
+type FeasibilityReport struct {
+	Ship         string  `json:"ship"`
+	MaxRange     float64 `json:"max_range"`
+	FuelCapacity float64 `json:"fuel_capacity"`
+
+	Issues []FeasibilityIssue `json:"issues"`
+	// Injections planned on the route
+	Injections InjectionCount `json:"injections"`
+	// Injections needed on top for the jumps that are too long
+	SuggestedInjections InjectionCount `json:"suggested_injections"`
+
+	// Set when any issue is an error
+	Blocking bool `json:"blocking"`
+}
+
+func (r *FeasibilityReport) AddIssue(issue FeasibilityIssue) {
+	r.Issues = append(r.Issues, issue)
+	if issue.Severity == FeasibilityError {
+		r.Blocking = true
+	}
+}
@@ -8,17 +8,26 @@ import (
 
 // ExpeditionIndex tracks all expeditions and the currently active one
 type ExpeditionIndex struct {
-	ActiveExpeditionID *string             `json:"active_expedition_id"`
-	Expeditions        []ExpeditionSummary `json:"expeditions"`
+	// Active expedition of the commander logged in
+	ActiveExpeditionID *string `json:"active_expedition_id"`
+	// Active expeditions of the other commanders, keyed by FID. They are
+	// swapped in for ActiveExpeditionID when their commander logs in.
+	ParkedExpeditions map[string]string   `json:"parked_expeditions,omitempty"`
+	Expeditions       []ExpeditionSummary `json:"expeditions"`
 }
 
 // ExpeditionSummary provides overview info for expedition listing
 type ExpeditionSummary struct {
 	ID          string           `json:"id"`
 	Name        string           `json:"name"`
-	Status      ExpeditionStatus `json:"status" ts_type:"'planned'|'active'|'completed'|'ended'"`
+	Status      ExpeditionStatus `json:"status" ts_type:"'planned'|'active'|'paused'|'completed'|'ended'"`
 	CreatedAt   time.Time        `json:"created_at"`
 	LastUpdated time.Time        `json:"last_updated"`
+
+	// Totals over the jump history
+	FuelScooped float64 `json:"fuel_scooped,omitempty"`
+	// Seconds
+	ScoopDuration float64 `json:"scoop_duration,omitempty"`
 }
 
 func (summary *ExpeditionSummary) LoadFull() (*Expedition, error) {
@@ -50,6 +59,24 @@ func TSaveIndex(t *database.Transaction, index *ExpeditionIndex) error {
 	return t.WriteJSON(database.IndexPath, index)
 }
 
+// IsActive reports whether the expedition is active for any commander
+func (e *ExpeditionIndex) IsActive(expeditionId string) bool {
+	if e.ActiveExpeditionID != nil && *e.ActiveExpeditionID == expeditionId {
+		return true
+	}
+	for _, id := range e.ParkedExpeditions {
+		if id == expeditionId {
+			return true
+		}
+	}
+	return false
+}
+
+// HasActive reports whether any commander has an active expedition
+func (e *ExpeditionIndex) HasActive() bool {
+	return e.ActiveExpeditionID != nil || len(e.ParkedExpeditions) > 0
+}
+
 func (e *ExpeditionIndex) LoadActiveExpedition() (*Expedition, error) {
 	if e.ActiveExpeditionID == nil {
 		return nil, nil
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"ed-expedition/database"
+	"os"
+	"time"
+)
+
+// JournalIndex caches what each journal file contains so Sync can skip the
+// parts of files it has already seen without reading them again.
+type JournalIndex struct {
+	// The journal directory the files are in, the index is dropped if it changes
+	Dir   string                      `json:"dir"`
+	Files map[string]*JournalFileInfo `json:"files"`
+}
+
+// JournalFileInfo describes a journal file, keyed by file name in JournalIndex
+type JournalFileInfo struct {
+	Part       int       `json:"part"`
+	FirstEvent time.Time `json:"first_event"`
+	LastEvent  time.Time `json:"last_event"`
+	// Bytes of complete lines read, everything before it is up to LastEvent
+	Offset       int64  `json:"offset"`
+	LastLineHash string `json:"last_line_hash"`
+}
+
+// LoadJournalIndex loads the index for the journal directory. Being a cache, an
+// empty index is always returned in place of one that is missing, unreadable or
+// made for another directory; the error is only worth logging.
+func LoadJournalIndex(dir string) (*JournalIndex, error) {
+	index, err := database.ReadJSON[JournalIndex](database.JournalIndexPath)
+	if err != nil || index.Dir != dir {
+		empty := &JournalIndex{Dir: dir, Files: map[string]*JournalFileInfo{}}
+		if os.IsNotExist(err) {
+			return empty, nil
+		}
+		return empty, err
+	}
+	if index.Files == nil {
+		index.Files = map[string]*JournalFileInfo{}
+	}
+	return index, nil
+}
+
+func SaveJournalIndex(index *JournalIndex) error {
+	return database.WriteJSON(database.JournalIndexPath, index)
+}
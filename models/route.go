@@ -5,6 +5,7 @@ import (
 	"ed-expedition/lib/vec"
 	"ed-expedition/migrations"
 	"maps"
+	"os"
 	"time"
 )
 
@@ -16,6 +17,7 @@ const (
 	FSDBoostInjectionBasic
 	FSDBoostInjectionStandard
 	FSDBoostInjectionPremium
+	FSDBoostWhiteDwarf
 )
 
 var AllFSDBoost = []struct {
@@ -27,6 +29,25 @@ var AllFSDBoost = []struct {
 	{FSDBoostInjectionBasic, "INJECTION_BASIC"},
 	{FSDBoostInjectionStandard, "INJECTION_STANDARD"},
 	{FSDBoostInjectionPremium, "INJECTION_PREMIUM"},
+	{FSDBoostWhiteDwarf, "WHITE_DWARF"},
+}
+
+// RangeMultiplier is how much the boost multiplies the jump range
+func (boost FSDBoost) RangeMultiplier() float64 {
+	switch boost {
+	case FSDBoostNeutron:
+		return 4
+	case FSDBoostInjectionBasic:
+		return 1.25
+	case FSDBoostInjectionStandard:
+		return 1.5
+	case FSDBoostInjectionPremium:
+		return 2
+	case FSDBoostWhiteDwarf:
+		return 1.5
+	default:
+		return 1
+	}
 }
 
 // Route represents an immutable path segment generated by a plotter
@@ -85,6 +106,13 @@ func LoadRoute(id string) (*Route, error) {
 	return database.ReadAndMigrateJSON[Route](path, migrations.RouteMigrations)
 }
 
+// ParseRoute decodes a route that is not stored in the database, migrating it
+// if it is from an older version
+func ParseRoute(data []byte) (*Route, error) {
+	route, _, err := database.UnmarshalAndMigrateJSON[Route](data, migrations.RouteMigrations)
+	return route, err
+}
+
 func SaveRoute(route *Route) error {
 	path := database.PathFor(database.ModelTypeRoutes, route.ID)
 	return database.WriteJSON(path, route)
@@ -94,3 +122,8 @@ func TSaveRoute(t *database.Transaction, route *Route) error {
 	path := database.PathFor(database.ModelTypeRoutes, route.ID)
 	return t.WriteJSON(path, route)
 }
+
+func DeleteRoute(id string) error {
+	path := database.PathFor(database.ModelTypeRoutes, id)
+	return os.Remove(path)
+}
@@ -14,10 +14,70 @@ const (
 	GalaxyAccepted GalaxyDecision = "accepted"
 )
 
+// PassengerJumpPolicy decides what to do with jumps made outside the
+// commander's own ship, i.e. in a taxi or as multicrew.
+type PassengerJumpPolicy string
+
+const (
+	// Ignore the jump entirely. The default.
+	PassengerJumpSkip PassengerJumpPolicy = "skip"
+	// Record the jump in the history as off-expedition, without advancing
+	// along the route.
+	PassengerJumpRecord PassengerJumpPolicy = "record"
+)
+
+// PreflightPolicy decides what a route that is not feasible with the current
+// loadout does to starting an expedition.
+type PreflightPolicy string
+
+const (
+	// Refuse to start when the check finds errors. The default.
+	PreflightBlock PreflightPolicy = "block"
+	// Start anyway, the report is only a warning.
+	PreflightWarn PreflightPolicy = "warn"
+)
+
 type Settings struct {
-	JournalDir     *string        `json:"journal_dir,omitempty"`
-	GalaxyDecision GalaxyDecision `json:"galaxy_decision,omitempty"`
-	Debug          bool           `json:"debug,omitempty"`
+	JournalDir     *string             `json:"journal_dir,omitempty"`
+	GalaxyDecision GalaxyDecision      `json:"galaxy_decision,omitempty"`
+	Debug          bool                `json:"debug,omitempty"`
+	PassengerJumps PassengerJumpPolicy `json:"passenger_jumps,omitempty"`
+	// How often the journal directory is polled, in milliseconds. 0 means the
+	// default.
+	JournalPollInterval int `json:"journal_poll_interval,omitempty"`
+	// Warn about an upcoming neutron jump when the FSD is below this health,
+	// in percent. 0 means the default.
+	FSDHealthAlert int `json:"fsd_health_alert,omitempty"`
+	// Plot a route back after this many jumps off route, 0 disables it
+	RejoinAfter int `json:"rejoin_after,omitempty"`
+	// What the pre-flight check does when starting an expedition
+	Preflight PreflightPolicy `json:"preflight,omitempty"`
+}
+
+const DefaultFSDHealthAlert = 80
+
+// FSDHealthThreshold returns the FSD health alert threshold from 0 to 1
+func (s *Settings) FSDHealthThreshold() float64 {
+	if s.FSDHealthAlert <= 0 {
+		return DefaultFSDHealthAlert / 100.0
+	}
+	return float64(s.FSDHealthAlert) / 100
+}
+
+// PassengerJumpPolicy returns the configured policy, defaulting to skip
+func (s *Settings) PassengerJumpPolicy() PassengerJumpPolicy {
+	if s.PassengerJumps == "" {
+		return PassengerJumpSkip
+	}
+	return s.PassengerJumps
+}
+
+// PreflightPolicy returns the configured policy, defaulting to block
+func (s *Settings) PreflightPolicy() PreflightPolicy {
+	if s.Preflight == "" {
+		return PreflightBlock
+	}
+	return s.Preflight
 }
 
 func LoadSettings() (*Settings, error) {
@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// ExpeditionStats summarises the jump history of an expedition. Distances are
+// in light years, fuel in tons and durations in seconds.
+type ExpeditionStats struct {
+	ComputedAt time.Time `json:"computed_at"`
+
+	Jumps           int     `json:"jumps"`
+	TotalDistance   float64 `json:"total_distance"`
+	OnRouteDistance float64 `json:"on_route_distance"`
+	// Runs of jumps off the route
+	Detours        int     `json:"detours"`
+	DetourJumps    int     `json:"detour_jumps"`
+	DetourDistance float64 `json:"detour_distance"`
+	AverageJump    float64 `json:"average_jump"`
+
+	Sessions     []PlaySession `json:"sessions"`
+	PlayTime     float64       `json:"play_time"`
+	JumpsPerHour float64       `json:"jumps_per_hour"`
+
+	FuelUsed     float64 `json:"fuel_used"`
+	FuelScooped  float64 `json:"fuel_scooped"`
+	BoostedJumps int     `json:"boosted_jumps"`
+	Deaths       int     `json:"deaths"`
+
+	// 0 to 100, how far along the baked route the commander got
+	Completion float64 `json:"completion"`
+}
+
+// PlaySession is a stretch of jumps without a long break in between
+type PlaySession struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	Jumps int       `json:"jumps"`
+	// Seconds, each jump after the first adds the time since the entry before
+	// it up to the AFK gap
+	Duration float64 `json:"duration"`
+}
@@ -0,0 +1,98 @@
+package plotters
+
+import (
+	"ed-expedition/models"
+	"fmt"
+)
+
+// CheckFeasibility checks that the ship of loadout can fly route as planned.
+// It assumes a full tank at the start and that the commander scoops to full at
+// every scoopable star.
+func CheckFeasibility(route *models.Route, loadout *models.Loadout) (*models.FeasibilityReport, error) {
+	fsd, err := getFsd(loadout.FSD.Item)
+	if err != nil {
+		return nil, err
+	}
+
+	maxRange := maxJumpRange(loadout, fsd)
+	tank := loadout.FuelCapacity.Main
+	report := &models.FeasibilityReport{
+		Ship:         loadout.ShipName,
+		MaxRange:     maxRange,
+		FuelCapacity: tank,
+		Issues:       []models.FeasibilityIssue{},
+	}
+	if report.Ship == "" {
+		report.Ship = loadout.Ship
+	}
+
+	fuel := tank
+	lastScoop := 0
+	stretch := 0.0
+	dry := false
+	for i := 0; i < len(route.Jumps)-1; i++ {
+		from, to := route.Jumps[i], route.Jumps[i+1]
+		if from.Scoopable {
+			fuel = tank
+			lastScoop = i
+			stretch = 0
+			dry = false
+		}
+
+		boost := models.FSDBoostNone
+		if from.FSDBoost != nil {
+			boost = *from.FSDBoost
+			report.Injections.Add(boost)
+		}
+
+		jumpRange := maxRange * boost.RangeMultiplier()
+		if to.Distance > jumpRange {
+			suggested := calculateMinFSDBoost(to.Distance, maxRange)
+			issue := models.FeasibilityIssue{
+				Kind:           models.FeasibilityOutOfRange,
+				Severity:       models.FeasibilityWarning,
+				FromIndex:      i,
+				ToIndex:        i + 1,
+				SystemName:     from.SystemName,
+				Distance:       to.Distance,
+				Range:          jumpRange,
+				SuggestedBoost: &suggested,
+				Message: fmt.Sprintf(
+					"The %.2f ly jump to %s is longer than the %.2f ly range, it needs an FSD injection",
+					to.Distance, to.SystemName, jumpRange,
+				),
+			}
+			if suggested == models.FSDBoostNeutron {
+				issue.Severity = models.FeasibilityError
+				issue.Message = fmt.Sprintf(
+					"The %.2f ly jump to %s is longer than the %.2f ly range, only a neutron supercharge reaches it",
+					to.Distance, to.SystemName, jumpRange,
+				)
+			} else {
+				report.SuggestedInjections.Add(suggested)
+			}
+			report.AddIssue(issue)
+		}
+
+		fuel -= fuelCost(loadout, fsd, jumpRange, min(to.Distance, jumpRange))
+		stretch += to.Distance
+		if fuel < 0 && !dry {
+			// One issue per stretch, the next scoopable star starts over
+			dry = true
+			report.AddIssue(models.FeasibilityIssue{
+				Kind:       models.FeasibilityOutOfFuel,
+				Severity:   models.FeasibilityError,
+				FromIndex:  lastScoop,
+				ToIndex:    i + 1,
+				SystemName: route.Jumps[lastScoop].SystemName,
+				Distance:   stretch,
+				Message: fmt.Sprintf(
+					"The tank runs dry on the way to %s, %.2f ly after the last scoopable star %s",
+					to.SystemName, stretch, route.Jumps[lastScoop].SystemName,
+				),
+			})
+		}
+	}
+
+	return report, nil
+}
@@ -0,0 +1,98 @@
+package plotters
+
+import (
+	"ed-expedition/models"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type CheckFeasibilitySuite struct {
+	suite.Suite
+	loadout  *models.Loadout
+	maxRange float64
+}
+
+func TestCheckFeasibilitySuite(t *testing.T) {
+	suite.Run(t, new(CheckFeasibilitySuite))
+}
+
+func (s *CheckFeasibilitySuite) SetupSuite() {
+	s.loadout = &models.Loadout{
+		ShipName:     "Explorer",
+		UnladenMass:  300,
+		FuelCapacity: models.FuelCapacity{Main: 32},
+	}
+	s.loadout.FSD.Item = "int_hyperdrive_size5_class5"
+
+	fsd, err := getFsd(s.loadout.FSD.Item)
+	s.Require().NoError(err)
+	s.maxRange = maxJumpRange(s.loadout, fsd)
+}
+
+func (s *CheckFeasibilitySuite) jump(name string, distance float64, scoopable bool, boost *models.FSDBoost) models.RouteJump {
+	return models.RouteJump{SystemName: name, Distance: distance, Scoopable: scoopable, FSDBoost: boost}
+}
+
+func (s *CheckFeasibilitySuite) TestFeasibleRoute() {
+	route := &models.Route{Jumps: []models.RouteJump{
+		s.jump("Start", 0, true, nil),
+		s.jump("A", s.maxRange*0.9, true, nil),
+		s.jump("B", s.maxRange*0.9, true, nil),
+	}}
+
+	report, err := CheckFeasibility(route, s.loadout)
+	s.Require().NoError(err)
+	s.Empty(report.Issues)
+	s.False(report.Blocking)
+	s.Equal("Explorer", report.Ship)
+}
+
+func (s *CheckFeasibilitySuite) TestFlagsRangeFuelAndInjections() {
+	basic := models.FSDBoostInjectionBasic
+	route := &models.Route{Jumps: []models.RouteJump{
+		s.jump("Start", 0, true, nil),
+		s.jump("A", 30, false, nil),
+		s.jump("B", s.maxRange*1.4, false, nil),
+		s.jump("C", s.maxRange*3, false, nil),
+		s.jump("D", s.maxRange*0.9, false, &basic),
+		s.jump("E", s.maxRange*1.2, false, nil),
+		s.jump("F", s.maxRange, false, nil),
+		s.jump("G", s.maxRange, false, nil),
+		s.jump("H", s.maxRange, true, nil),
+		s.jump("I", s.maxRange, false, nil),
+	}}
+
+	report, err := CheckFeasibility(route, s.loadout)
+	s.Require().NoError(err)
+	s.Require().Len(report.Issues, 3)
+
+	tooLong := report.Issues[0]
+	s.Equal(models.FeasibilityOutOfRange, tooLong.Kind)
+	s.Equal(models.FeasibilityWarning, tooLong.Severity)
+	s.Equal(1, tooLong.FromIndex)
+	s.Require().NotNil(tooLong.SuggestedBoost)
+	s.Equal(models.FSDBoostInjectionStandard, *tooLong.SuggestedBoost)
+
+	impossible := report.Issues[1]
+	s.Equal(models.FeasibilityOutOfRange, impossible.Kind)
+	s.Equal(models.FeasibilityError, impossible.Severity)
+	s.Equal(2, impossible.FromIndex)
+
+	dry := report.Issues[2]
+	s.Equal(models.FeasibilityOutOfFuel, dry.Kind)
+	s.Equal(models.FeasibilityError, dry.Severity)
+	s.Equal(0, dry.FromIndex)
+	s.Equal(8, dry.ToIndex)
+
+	s.True(report.Blocking)
+	s.Equal(models.InjectionCount{Basic: 1}, report.Injections)
+	s.Equal(models.InjectionCount{Standard: 1}, report.SuggestedInjections)
+}
+
+func (s *CheckFeasibilitySuite) TestUnknownFSD() {
+	loadout := *s.loadout
+	loadout.FSD.Item = "unknown"
+	_, err := CheckFeasibility(&models.Route{}, &loadout)
+	s.Error(err)
+}
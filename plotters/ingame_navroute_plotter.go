@@ -0,0 +1,120 @@
+package plotters
+
+import (
+	"ed-expedition/journal"
+	"ed-expedition/lib/form"
+	"ed-expedition/lib/job"
+	"ed-expedition/lib/ptr"
+	"ed-expedition/lib/vec"
+	"ed-expedition/models"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	wailsLogger "github.com/wailsapp/wails/v2/pkg/logger"
+)
+
+// InGameNavRoutePlotter does not plot anything itself, it turns the route
+// currently plotted in the galaxy map (NavRoute.json) into a models.Route.
+type InGameNavRoutePlotter struct {
+	// Source returns the current in-game route, normally journal.ReadNavRoute
+	// for the journal directory.
+	Source func() (*journal.NavRouteFile, error)
+}
+
+func (p InGameNavRoutePlotter) String() string { return "In-Game Route (NavRoute.json)" }
+
+func (p InGameNavRoutePlotter) ProgressType() job.PhaseType {
+	return job.PhaseTypeIndeterminate
+}
+
+// Plot converts the in-game route. from and to are optional, when given they
+// must match the start and end of the in-game route so an outdated plot is not
+// imported by accident.
+func (p InGameNavRoutePlotter) Plot(
+	from, to string,
+	inputs form.InputValues,
+	loadout *models.Loadout,
+	logger wailsLogger.Logger,
+	tracker *job.ProgressTracker,
+) (*models.Route, error) {
+	tag := "[InGameNavRoutePlotter]"
+
+	navRoute, err := p.Source()
+	if err != nil {
+		return nil, err
+	}
+	if len(navRoute.Route) < 2 {
+		return nil, fmt.Errorf("In-game route needs at least two systems, got %d", len(navRoute.Route))
+	}
+
+	first := navRoute.Route[0]
+	last := navRoute.Route[len(navRoute.Route)-1]
+	if from != "" && !strings.EqualFold(from, first.StarSystem) {
+		return nil, fmt.Errorf("In-game route starts at '%s', not '%s'", first.StarSystem, from)
+	}
+	if to != "" && !strings.EqualFold(to, last.StarSystem) {
+		return nil, fmt.Errorf("In-game route ends at '%s', not '%s'", last.StarSystem, to)
+	}
+
+	route := NavRouteToRoute(navRoute)
+
+	if loadout != nil {
+		if fsd, err := getFsd(loadout.FSD.Item); err == nil {
+			computeFSDBoostForRoute(route, maxJumpRange(loadout, fsd))
+		} else {
+			logger.Warning(fmt.Sprintf("%s unable to compute FSD boosts: %s", tag, err.Error()))
+		}
+	}
+
+	logger.Info(fmt.Sprintf("%s imported in-game route: %d jumps", tag, len(route.Jumps)))
+	return route, nil
+}
+
+// NavRouteToRoute converts the content of NavRoute.json to a route, with the
+// first jump being the system the route was plotted from. The route must have
+// at least one stop.
+func NavRouteToRoute(navRoute *journal.NavRouteFile) *models.Route {
+	jumps := make([]models.RouteJump, len(navRoute.Route))
+	var prev *vec.Vec3
+	for i, stop := range navRoute.Route {
+		jumps[i] = models.RouteJump{
+			SystemName: stop.StarSystem,
+			SystemID:   stop.SystemAddress,
+			Scoopable:  stop.Scoopable(),
+			Meta:       map[string]any{"journal_star_class": stop.StarClass},
+		}
+
+		if len(stop.StarPos) == 3 {
+			pos := vec.NewVec3FromSlice(stop.StarPos)
+			jumps[i].Position = ptr.New(pos)
+			if prev != nil {
+				jumps[i].Distance = prev.Distance(pos)
+			}
+			prev = &pos
+		} else {
+			prev = nil
+		}
+	}
+
+	first := navRoute.Route[0]
+	last := navRoute.Route[len(navRoute.Route)-1]
+
+	return &models.Route{
+		Version:       1,
+		ID:            uuid.New().String(),
+		Name:          fmt.Sprintf("%s → %s", first.StarSystem, last.StarSystem),
+		Plotter:       "ingame_navroute",
+		PlotterParams: map[string]any{"from": first.StarSystem, "to": last.StarSystem},
+		PlotterMetadata: map[string]any{
+			"plotted_at": navRoute.Timestamp,
+		},
+		Jumps:     jumps,
+		CreatedAt: time.Now(),
+	}
+}
+
+func (p InGameNavRoutePlotter) InputConfig() form.InputConfig {
+	return form.InputConfig{}
+}
@@ -6,19 +6,30 @@ import (
 	"ed-expedition/models"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	wailsLogger "github.com/wailsapp/wails/v2/pkg/logger"
 )
 
 type AppStateService struct {
-	State        *models.AppState
-	watcher      *journal.Watcher
+	State         *models.AppState
+	watcher       *journal.Watcher
+	commanderChan chan *journal.CommanderEvent
 	loadoutChan   chan *journal.LoadoutEvent
+	loadGameChan  chan *journal.LoadGameEvent
+	swapChan      chan *journal.ShipyardSwapEvent
+	newShipChan   chan *journal.ShipyardNewEvent
+	sellShipChan  chan *journal.SellShipOnRebuyEvent
 	fsdJumpChan   chan *journal.FSDJumpEvent
 	locationChan  chan *journal.LocationEvent
 	syncStateChan chan models.JournalSync
 	logger        wailsLogger.Logger
+
+	passengerJumps models.PassengerJumpPolicy
+
+	// Events arrive on separate goroutines but share the commander map
+	mu sync.Mutex
 }
 
 func NewAppStateService(logger wailsLogger.Logger) *AppStateService {
@@ -30,11 +41,22 @@ func NewAppStateService(logger wailsLogger.Logger) *AppStateService {
 	return &AppStateService{
 		State:  state,
 		logger: logger,
+
+		passengerJumps: models.PassengerJumpSkip,
 	}
 }
 
 func (s *AppStateService) SetWatcher(w *journal.Watcher) {
 	s.watcher = w
+	if s.State.CurrentCommander != nil {
+		w.SetCommander(*s.State.CurrentCommander)
+	}
+}
+
+// SetPassengerJumpPolicy sets whether locations reached in a taxi, as
+// multicrew or on foot update the last known location.
+func (s *AppStateService) SetPassengerJumpPolicy(policy models.PassengerJumpPolicy) {
+	s.passengerJumps = policy
 }
 
 func (s *AppStateService) Start() {
@@ -42,95 +64,248 @@ func (s *AppStateService) Start() {
 		return
 	}
 
-	s.loadoutChan = s.watcher.Loadout.Subscribe()
+	commanderChan := s.watcher.Commander.Subscribe()
+	s.commanderChan = commanderChan
 
 	go func() {
-		for event := range s.loadoutChan {
-			if s.State.LastKnownLoadout != nil && s.State.LastKnownLoadout.Timestamp.After(event.Timestamp) {
-				continue
-			}
+		for event := range commanderChan {
+			s.handleCommander(event)
+		}
+	}()
 
-			s.State.LastKnownLoadout = transformLoadoutEventToStateLoadout(event)
-			if err := models.SaveAppState(s.State); err != nil {
-				// TODO: Proper error handling (log, retry, etc.)
-				s.logger.Error(fmt.Sprintf("[AppStateService] failed to SaveAppState on loadout event: %v", err))
-			}
-			s.logger.Info(fmt.Sprintf(
-				"[AppStateService] Saved loadout at %v",
-				s.State.LastKnownLoadout.Timestamp.Format(time.RFC3339),
-			))
+	loadoutChan := s.watcher.Loadout.Subscribe()
+	s.loadoutChan = loadoutChan
+
+	go func() {
+		for event := range loadoutChan {
+			s.handleLoadout(event)
 		}
 	}()
 
-	s.fsdJumpChan = s.watcher.FSDJump.Subscribe()
+	loadGameChan := s.watcher.LoadGame.Subscribe()
+	s.loadGameChan = loadGameChan
 
 	go func() {
-		for event := range s.fsdJumpChan {
-			if s.State.LastKnownLocation != nil && s.State.LastKnownLocation.Timestamp.After(event.Timestamp) {
-				continue
-			}
-			if s.State.LastKnownLocation == nil {
-				s.State.LastKnownLocation = &models.Location{}
-			}
+		for event := range loadGameChan {
+			s.handleShipSwitch(event.FID, event.Timestamp, event.ShipID, "load game")
+		}
+	}()
 
-			s.State.LastKnownLocation.Timestamp = event.Timestamp
-			s.State.LastKnownLocation.SystemID = event.SystemAddress
+	swapChan := s.watcher.ShipyardSwap.Subscribe()
+	s.swapChan = swapChan
 
-			if err := models.SaveAppState(s.State); err != nil {
-				// TODO: Proper error handling (log, retry, etc.)
-				s.logger.Error(fmt.Sprintf("[AppStateService] failed to SaveAppState on fsd jump event: %v", err))
+	go func() {
+		for event := range swapChan {
+			s.handleShipSwitch(event.CommanderFID, event.Timestamp, event.ShipID, "shipyard swap")
+			if event.SellShipID != nil {
+				s.handleShipSold(event.CommanderFID, *event.SellShipID, "shipyard swap")
 			}
-			s.logger.Info(fmt.Sprintf(
-				"[AppStateService] Saved location at %v",
-				s.State.LastKnownLocation.Timestamp.Format(time.RFC3339),
-			))
 		}
 	}()
 
-	s.locationChan = s.watcher.Location.Subscribe()
+	newShipChan := s.watcher.ShipyardNew.Subscribe()
+	s.newShipChan = newShipChan
 
 	go func() {
-		for event := range s.locationChan {
-			if s.State.LastKnownLocation != nil && s.State.LastKnownLocation.Timestamp.After(event.Timestamp) {
-				continue
-			}
-			if s.State.LastKnownLocation == nil {
-				s.State.LastKnownLocation = &models.Location{}
-			}
+		for event := range newShipChan {
+			s.handleShipSwitch(event.CommanderFID, event.Timestamp, event.NewShipID, "shipyard new")
+		}
+	}()
 
-			s.State.LastKnownLocation.Timestamp = event.Timestamp
-			s.State.LastKnownLocation.SystemID = event.SystemAddress
+	sellShipChan := s.watcher.SellShipOnRebuy.Subscribe()
+	s.sellShipChan = sellShipChan
 
-			if err := models.SaveAppState(s.State); err != nil {
-				// TODO: Proper error handling (log, retry, etc.)
-				s.logger.Error(fmt.Sprintf("[AppStateService] failed to SaveAppState on location event: %v", err))
-				continue
-			}
-			s.logger.Info(fmt.Sprintf(
-				"[AppStateService] Saved location at %v",
-				s.State.LastKnownLocation.Timestamp.Format(time.RFC3339),
-			))
+	go func() {
+		for event := range sellShipChan {
+			s.handleShipSold(event.CommanderFID, event.SellShipID, "sell ship")
+		}
+	}()
+
+	fsdJumpChan := s.watcher.FSDJump.Subscribe()
+	s.fsdJumpChan = fsdJumpChan
+
+	go func() {
+		for event := range fsdJumpChan {
+			s.handleLocation(event.CommanderFID, event.Timestamp, event.SystemAddress, event.InOwnShip(), "fsd jump")
+		}
+	}()
+
+	locationChan := s.watcher.Location.Subscribe()
+	s.locationChan = locationChan
+
+	go func() {
+		for event := range locationChan {
+			s.handleLocation(event.CommanderFID, event.Timestamp, event.SystemAddress, event.InOwnShip(), "location")
 		}
 	}()
 
-	s.syncStateChan = s.watcher.SyncState.Subscribe()
+	syncStateChan := s.watcher.SyncState.Subscribe()
+	s.syncStateChan = syncStateChan
 
 	go func() {
-		for syncState := range s.syncStateChan {
+		for syncState := range syncStateChan {
+			s.mu.Lock()
 			s.State.JournalSync = &syncState
+			// Keyed by the commander the watcher read it for, the Commander
+			// event may not have been handled yet
+			if commander := s.State.Commander(syncState.CommanderFID); commander != nil {
+				commander.JournalSync = &syncState
+			}
+			s.mu.Unlock()
 		}
 	}()
 }
 
+func (s *AppStateService) handleCommander(event *journal.CommanderEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.State.CurrentCommander != nil && *s.State.CurrentCommander == event.FID {
+		return
+	}
+
+	s.State.SwitchCommander(event.FID, event.Name)
+	if err := models.SaveAppState(s.State); err != nil {
+		s.logger.Error(fmt.Sprintf("[AppStateService] failed to SaveAppState on commander event: %v", err))
+	}
+	s.logger.Info(fmt.Sprintf("[AppStateService] Switched to commander %s (%s)", event.Name, event.FID))
+}
+
+func (s *AppStateService) handleLoadout(event *journal.LoadoutEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	loadout := transformLoadoutEventToStateLoadout(event)
+	updated := false
+
+	if commander := s.State.Commander(event.CommanderFID); commander != nil {
+		if !commander.SetLoadout(loadout) {
+			return
+		}
+		if s.State.IsCurrentCommander(event.CommanderFID) {
+			s.State.LastKnownLoadout = commander.LastKnownLoadout
+		}
+		updated = true
+	} else if s.State.LastKnownLoadout == nil || !s.State.LastKnownLoadout.Timestamp.After(event.Timestamp) {
+		// Without a commander there is nowhere to keep the other ships
+		s.State.LastKnownLoadout = loadout
+		updated = true
+	}
+	if !updated {
+		return
+	}
+
+	if err := models.SaveAppState(s.State); err != nil {
+		// TODO: Proper error handling (log, retry, etc.)
+		s.logger.Error(fmt.Sprintf("[AppStateService] failed to SaveAppState on loadout event: %v", err))
+	}
+	s.logger.Info(fmt.Sprintf(
+		"[AppStateService] Saved loadout of ship %d at %v",
+		loadout.ShipID,
+		loadout.Timestamp.Format(time.RFC3339),
+	))
+}
+
+func (s *AppStateService) handleShipSwitch(commanderFID string, timestamp time.Time, shipID int, source string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	commander := s.State.Commander(commanderFID)
+	if commander == nil || !commander.SwitchShip(shipID, timestamp) {
+		return
+	}
+	if s.State.IsCurrentCommander(commanderFID) {
+		s.State.LastKnownLoadout = commander.LastKnownLoadout
+	}
+
+	if err := models.SaveAppState(s.State); err != nil {
+		s.logger.Error(fmt.Sprintf("[AppStateService] failed to SaveAppState on %s event: %v", source, err))
+		return
+	}
+	s.logger.Info(fmt.Sprintf("[AppStateService] Switched to ship %d on %s", shipID, source))
+}
+
+func (s *AppStateService) handleShipSold(commanderFID string, shipID int, source string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	commander := s.State.Commander(commanderFID)
+	if commander == nil || !commander.RemoveShip(shipID) {
+		return
+	}
+
+	if err := models.SaveAppState(s.State); err != nil {
+		s.logger.Error(fmt.Sprintf("[AppStateService] failed to SaveAppState on %s event: %v", source, err))
+		return
+	}
+	s.logger.Info(fmt.Sprintf("[AppStateService] Removed sold ship %d on %s", shipID, source))
+}
+
+func (s *AppStateService) handleLocation(commanderFID string, timestamp time.Time, systemID int64, inOwnShip bool, source string) {
+	if !inOwnShip && s.passengerJumps != models.PassengerJumpRecord {
+		s.logger.Trace(fmt.Sprintf("[AppStateService] Ignoring %s event outside own ship", source))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	location := &models.Location{Timestamp: timestamp, SystemID: systemID}
+	updated := false
+
+	if commander := s.State.Commander(commanderFID); commander != nil &&
+		(commander.LastKnownLocation == nil || !commander.LastKnownLocation.Timestamp.After(timestamp)) {
+		commander.LastKnownLocation = location
+		updated = true
+	}
+	if s.State.IsCurrentCommander(commanderFID) &&
+		(s.State.LastKnownLocation == nil || !s.State.LastKnownLocation.Timestamp.After(timestamp)) {
+		s.State.LastKnownLocation = location
+		updated = true
+	}
+	if !updated {
+		return
+	}
+
+	if err := models.SaveAppState(s.State); err != nil {
+		// TODO: Proper error handling (log, retry, etc.)
+		s.logger.Error(fmt.Sprintf("[AppStateService] failed to SaveAppState on %s event: %v", source, err))
+		return
+	}
+	s.logger.Info(fmt.Sprintf(
+		"[AppStateService] Saved location at %v",
+		timestamp.Format(time.RFC3339),
+	))
+}
 
 func (s *AppStateService) Stop() error {
 	if s.watcher == nil {
 		return nil
 	}
+	if s.commanderChan != nil {
+		s.watcher.Commander.Unsubscribe(s.commanderChan)
+		s.commanderChan = nil
+	}
 	if s.loadoutChan != nil {
 		s.watcher.Loadout.Unsubscribe(s.loadoutChan)
 		s.loadoutChan = nil
 	}
+	if s.loadGameChan != nil {
+		s.watcher.LoadGame.Unsubscribe(s.loadGameChan)
+		s.loadGameChan = nil
+	}
+	if s.swapChan != nil {
+		s.watcher.ShipyardSwap.Unsubscribe(s.swapChan)
+		s.swapChan = nil
+	}
+	if s.newShipChan != nil {
+		s.watcher.ShipyardNew.Unsubscribe(s.newShipChan)
+		s.newShipChan = nil
+	}
+	if s.sellShipChan != nil {
+		s.watcher.SellShipOnRebuy.Unsubscribe(s.sellShipChan)
+		s.sellShipChan = nil
+	}
 	if s.fsdJumpChan != nil {
 		s.watcher.FSDJump.Unsubscribe(s.fsdJumpChan)
 		s.fsdJumpChan = nil
@@ -161,6 +336,10 @@ func transformLoadoutEventToStateLoadout(event *journal.LoadoutEvent) *models.Lo
 
 	loadout := &models.Loadout{
 		Timestamp:   event.Timestamp,
+		ShipID:      event.ShipID,
+		Ship:        event.Ship,
+		ShipName:    event.ShipName,
+		ShipIdent:   event.ShipIdent,
 		UnladenMass: event.UnladenMass,
 		FuelCapacity: models.FuelCapacity{
 			Main:    event.FuelCapacity.Main,
@@ -0,0 +1,127 @@
+package services
+
+import (
+	"ed-expedition/database"
+	"ed-expedition/journal"
+	"ed-expedition/models"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type AppStateServiceTestSuite struct {
+	suite.Suite
+	tmpDir  string
+	watcher *journal.Watcher
+	service *AppStateService
+}
+
+func (s *AppStateServiceTestSuite) SetupTest() {
+	var err error
+	s.tmpDir, err = os.MkdirTemp("", "app-state-test-*")
+	s.Require().NoError(err)
+	os.Setenv("ED_EXPEDITION_DATA_DIR", s.tmpDir)
+	s.Require().NoError(database.InitDirectories())
+
+	s.watcher, err = journal.NewWatcher(s.tmpDir, &TestLogger{})
+	s.Require().NoError(err)
+
+	s.service = NewAppStateService(&TestLogger{})
+	s.service.SetWatcher(s.watcher)
+	s.service.Start()
+	s.watcher.Start()
+}
+
+func (s *AppStateServiceTestSuite) TearDownTest() {
+	if s.service != nil {
+		s.service.Stop()
+	}
+	if s.watcher != nil {
+		s.watcher.Close()
+	}
+	if s.tmpDir != "" {
+		os.RemoveAll(s.tmpDir)
+	}
+}
+
+func (s *AppStateServiceTestSuite) activeShip() (shipID int, loadout *models.Loadout, ok bool) {
+	s.service.mu.Lock()
+	defer s.service.mu.Unlock()
+
+	commander := s.service.State.Commanders["F1"]
+	if commander == nil || commander.ActiveShip == nil {
+		return 0, nil, false
+	}
+	return commander.ActiveShip.ShipID, s.service.State.LastKnownLoadout, true
+}
+
+func (s *AppStateServiceTestSuite) TestKeepsLoadoutPerShip() {
+	simulateEvent(s.T(), s.tmpDir, `{"timestamp":"2025-12-20T10:00:00Z","event":"Commander","FID":"F1","Name":"Jameson"}`)
+	simulateEvent(s.T(), s.tmpDir, loadoutLine("2025-12-20T10:00:01Z", 1, "anaconda", 400))
+
+	s.Eventually(func() bool {
+		shipID, loadout, ok := s.activeShip()
+		return ok && shipID == 1 && loadout != nil && loadout.ShipID == 1
+	}, time.Second, 10*time.Millisecond)
+
+	simulateEvent(s.T(), s.tmpDir, `{"timestamp":"2025-12-20T10:05:00Z","event":"ShipyardSwap","ShipType":"type9","ShipID":2,"StoreOldShip":"anaconda","StoreShipID":1,"MarketID":1}`)
+
+	s.Eventually(func() bool {
+		shipID, loadout, ok := s.activeShip()
+		return ok && shipID == 2 && loadout == nil
+	}, time.Second, 10*time.Millisecond, "swapping to an unknown ship clears the current loadout")
+
+	simulateEvent(s.T(), s.tmpDir, loadoutLine("2025-12-20T10:05:01Z", 2, "type9", 900))
+
+	s.Eventually(func() bool {
+		_, loadout, _ := s.activeShip()
+		return loadout != nil && loadout.ShipID == 2
+	}, time.Second, 10*time.Millisecond)
+
+	explorer := s.service.State.ShipLoadout(1)
+	s.Require().NotNil(explorer, "the stored ship keeps its loadout")
+	s.Equal(400.0, explorer.UnladenMass)
+	s.Len(s.service.State.ShipLoadouts(), 2)
+
+	simulateEvent(s.T(), s.tmpDir, `{"timestamp":"2025-12-20T10:10:00Z","event":"SellShipOnRebuy","ShipType":"anaconda","System":"Sol","SellShipId":1,"ShipPrice":1000}`)
+
+	s.Eventually(func() bool {
+		s.service.mu.Lock()
+		defer s.service.mu.Unlock()
+		return s.service.State.ShipLoadout(1) == nil
+	}, time.Second, 10*time.Millisecond)
+}
+
+func (s *AppStateServiceTestSuite) TestForgetsShipSoldInSwap() {
+	simulateEvent(s.T(), s.tmpDir, `{"timestamp":"2025-12-20T10:00:00Z","event":"Commander","FID":"F1","Name":"Jameson"}`)
+	simulateEvent(s.T(), s.tmpDir, loadoutLine("2025-12-20T10:00:01Z", 1, "anaconda", 400))
+
+	s.Eventually(func() bool {
+		_, loadout, ok := s.activeShip()
+		return ok && loadout != nil && loadout.ShipID == 1
+	}, time.Second, 10*time.Millisecond)
+
+	simulateEvent(s.T(), s.tmpDir, `{"timestamp":"2025-12-20T10:05:00Z","event":"ShipyardSwap","ShipType":"type9","ShipID":2,"SellOldShip":"anaconda","SellShipID":1,"MarketID":1}`)
+
+	s.Eventually(func() bool {
+		s.service.mu.Lock()
+		defer s.service.mu.Unlock()
+		return s.service.State.ShipLoadout(1) == nil
+	}, time.Second, 10*time.Millisecond, "the ship sold in the swap is forgotten")
+
+	shipID, _, ok := s.activeShip()
+	s.True(ok)
+	s.Equal(2, shipID)
+}
+
+func TestAppStateServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(AppStateServiceTestSuite))
+}
+
+func loadoutLine(timestamp string, shipID int, ship string, unladenMass float64) string {
+	return fmt.Sprintf(`{"timestamp":"%s","event":"Loadout","Ship":"%s","ShipID":%d,"ShipName":"","ShipIdent":"","UnladenMass":%.1f,"FuelCapacity":{"Main":32,"Reserve":0.5},"Modules":[{"Slot":"FrameShiftDrive","Item":"int_hyperdrive_size5_class5","On":true,"Priority":0,"Health":1}]}`,
+		timestamp, ship, shipID, unladenMass)
+}
@@ -4,6 +4,7 @@ import (
 	"ed-expedition/journal"
 	"ed-expedition/lib/channels"
 	"ed-expedition/models"
+	"fmt"
 	"sync"
 	"time"
 
@@ -11,20 +12,70 @@ import (
 )
 
 type ExpeditionService struct {
+	// Serializes the event handlers, their timers and the methods called by
+	// the app, they all read and change the active expedition
+	mu sync.Mutex
+
 	Index              *models.ExpeditionIndex
 	activeExpedition   *models.Expedition
 	bakedRoute         *models.Route
 	currentJump        *models.JumpHistoryEntry
 	previouslyScooping bool
+	scoopingSince      time.Time
+	scoopSaveTimer     *time.Timer
+	scoopMu            sync.Mutex
+	commanderFID       string
+	commanderMu        sync.Mutex
+	passengerJumps     models.PassengerJumpPolicy
+	pendingRebuild     *HistoryRebuild
 
 	watcher         *journal.Watcher
+	commanderChan   chan *journal.CommanderEvent
 	fsdJumpChan     chan *journal.FSDJumpEvent
 	startJumpChan   chan *journal.StartJumpEvent
+	carrierJumpChan chan *journal.CarrierJumpEvent
+	locationChan    chan *journal.LocationEvent
+	jetConeChan     chan *journal.JetConeBoostEvent
+	synthesisChan   chan *journal.SynthesisEvent
 	fsdChargingChan chan bool
 	scoopingChan    chan bool
+	fuelScoopChan   chan *journal.FuelScoopEvent
 	fuelChan        chan *journal.FuelStatus
+	loadoutChan     chan *journal.LoadoutEvent
+	hullDamageChan  chan *journal.HullDamageEvent
+	afmuRepairsChan chan *journal.AfmuRepairsEvent
+	repairChan      chan *journal.RepairEvent
 	logger          wailsLogger.Logger
 
+	scanChan              chan *journal.ScanEvent
+	fssDiscoveryScanChan  chan *journal.FSSDiscoveryScanEvent
+	fssAllBodiesFoundChan chan *journal.FSSAllBodiesFoundEvent
+	saaSignalsFoundChan   chan *journal.SAASignalsFoundEvent
+	explorationSaveTimer  *time.Timer
+
+	// Boost picked up for the next jump, see expedition_boost.go
+	availableBoost models.FSDBoost
+	boostMu        sync.Mutex
+
+	// Known health of the ship, see expedition_damage.go
+	shipHealth         models.ShipHealth
+	fsdHealthThreshold float64
+	healthMu           sync.Mutex
+	healthSaveTimer    *time.Timer
+
+	// Route back after going off route, see expedition_rejoin.go
+	rejoin           *rejoinState
+	rejoinAfter      int
+	rejoinPlotter    RejoinPlotter
+	systemsAround    SystemsAround
+	rejoinGeneration int
+	rejoinMu         sync.Mutex
+
+	// Check of the route against the ship, see expedition_preflight.go
+	feasibilityChecker FeasibilityChecker
+	preflightPolicy    models.PreflightPolicy
+	preflightMu        sync.Mutex
+
 	jumpState     jumpState
 	jumpStateMu   sync.Mutex
 	chargingTimer *time.Timer
@@ -33,6 +84,10 @@ type ExpeditionService struct {
 	CompleteExpedition *channels.FanoutChannel[*models.Expedition]
 	CurrentJump        *channels.FanoutChannel[*models.JumpHistoryEntry]
 	FuelAlert          *channels.FanoutChannel[*FuelAlert]
+	BoostAlert         *channels.FanoutChannel[*BoostAlert]
+	DamageAlert        *channels.FanoutChannel[*DamageAlert]
+	Rejoin             *channels.FanoutChannel[*RejoinSuggestion]
+	ETA                *channels.FanoutChannel[*ETAPrediction]
 }
 
 func NewExpeditionService(logger wailsLogger.Logger, currentSystem int64) *ExpeditionService {
@@ -68,6 +123,9 @@ func NewExpeditionService(logger wailsLogger.Logger, currentSystem int64) *Exped
 		bakedRoute:         bakedRoute,
 		currentJump:        currentJump,
 		previouslyScooping: false,
+		passengerJumps:     models.PassengerJumpSkip,
+		fsdHealthThreshold: models.DefaultFSDHealthAlert / 100.0,
+		preflightPolicy:    models.PreflightBlock,
 
 		logger: logger,
 
@@ -83,6 +141,18 @@ func NewExpeditionService(logger wailsLogger.Logger, currentSystem int64) *Exped
 		FuelAlert: channels.NewFanoutChannel[*FuelAlert](
 			"FuelAlert", 0, 5*time.Millisecond, logger,
 		),
+		BoostAlert: channels.NewFanoutChannel[*BoostAlert](
+			"BoostAlert", 0, 5*time.Millisecond, logger,
+		),
+		DamageAlert: channels.NewFanoutChannel[*DamageAlert](
+			"DamageAlert", 0, 5*time.Millisecond, logger,
+		),
+		Rejoin: channels.NewFanoutChannel[*RejoinSuggestion](
+			"Rejoin", 0, 5*time.Millisecond, logger,
+		),
+		ETA: channels.NewFanoutChannel[*ETAPrediction](
+			"ETA", 0, 5*time.Millisecond, logger,
+		),
 	}
 }
 
@@ -95,39 +165,80 @@ func (e *ExpeditionService) Start() {
 		return
 	}
 
+	e.commanderChan = e.watcher.Commander.Subscribe()
+	handleEach(e, e.commanderChan, e.handleCommander)
+
 	e.fsdJumpChan = e.watcher.FSDJump.Subscribe()
-	go func() {
-		for event := range e.fsdJumpChan {
-			e.handleJump(event)
-			e.handleJumpFuel(event)
-		}
-	}()
+	handleEach(e, e.fsdJumpChan, func(event *journal.FSDJumpEvent) {
+		e.handleJump(event)
+		e.handleJumpFuel(event)
+	})
+
+	e.carrierJumpChan = e.watcher.CarrierJump.Subscribe()
+	handleEach(e, e.carrierJumpChan, e.handleCarrierJump)
+
+	e.jetConeChan = e.watcher.JetConeBoost.Subscribe()
+	handleEach(e, e.jetConeChan, e.handleJetConeBoost)
+
+	e.synthesisChan = e.watcher.Synthesis.Subscribe()
+	handleEach(e, e.synthesisChan, e.handleSynthesis)
 
 	e.startJumpChan = e.watcher.StartJump.Subscribe()
-	go func() {
-		for event := range e.startJumpChan {
-			e.handleStartJump(event)
-		}
-	}()
+	handleEach(e, e.startJumpChan, e.handleStartJump)
+
+	e.locationChan = e.watcher.Location.Subscribe()
+	handleEach(e, e.locationChan, e.handleLocation)
 
 	e.fsdChargingChan = e.watcher.FsdCharging.Subscribe()
-	go func() {
-		for event := range e.fsdChargingChan {
-			e.handleFsdCharging(event)
+	handleEach(e, e.fsdChargingChan, func(event bool) {
+		e.handleFsdCharging(event)
+		if event {
+			e.checkNextBoost()
 		}
-	}()
+	})
 
 	e.scoopingChan = e.watcher.Scooping.Subscribe()
-	go func() {
-		for event := range e.scoopingChan {
-			e.handleRefueling(event)
-		}
-	}()
+	handleEach(e, e.scoopingChan, e.handleRefueling)
+
+	e.fuelScoopChan = e.watcher.FuelScoop.Subscribe()
+	handleEach(e, e.fuelScoopChan, e.handleFuelScoop)
 
 	e.fuelChan = e.watcher.Fuel.Subscribe()
+	handleEach(e, e.fuelChan, e.handleFuelChange)
+
+	e.loadoutChan = e.watcher.Loadout.Subscribe()
+	handleEach(e, e.loadoutChan, e.handleLoadout)
+
+	e.hullDamageChan = e.watcher.HullDamage.Subscribe()
+	handleEach(e, e.hullDamageChan, e.handleHullDamage)
+
+	e.afmuRepairsChan = e.watcher.AfmuRepairs.Subscribe()
+	handleEach(e, e.afmuRepairsChan, e.handleAfmuRepairs)
+
+	e.repairChan = e.watcher.Repair.Subscribe()
+	handleEach(e, e.repairChan, e.handleRepair)
+
+	e.scanChan = e.watcher.Scan.Subscribe()
+	handleEach(e, e.scanChan, e.handleScan)
+
+	e.fssDiscoveryScanChan = e.watcher.FSSDiscoveryScan.Subscribe()
+	handleEach(e, e.fssDiscoveryScanChan, e.handleFSSDiscoveryScan)
+
+	e.fssAllBodiesFoundChan = e.watcher.FSSAllBodiesFound.Subscribe()
+	handleEach(e, e.fssAllBodiesFoundChan, e.handleFSSAllBodiesFound)
+
+	e.saaSignalsFoundChan = e.watcher.SAASignalsFound.Subscribe()
+	handleEach(e, e.saaSignalsFoundChan, e.handleSAASignalsFound)
+}
+
+// handleEach passes every event on the channel to handle, holding the service
+// lock so that no two handlers run at the same time
+func handleEach[T any](e *ExpeditionService, events chan T, handle func(T)) {
 	go func() {
-		for event := range e.fuelChan {
-			e.handleFuelChange(event)
+		for event := range events {
+			e.mu.Lock()
+			handle(event)
+			e.mu.Unlock()
 		}
 	}()
 }
@@ -136,6 +247,10 @@ func (e *ExpeditionService) Stop() error {
 	if e.watcher == nil {
 		return nil
 	}
+	if e.commanderChan != nil {
+		e.watcher.Commander.Unsubscribe(e.commanderChan)
+		e.commanderChan = nil
+	}
 	if e.fsdJumpChan != nil {
 		e.watcher.FSDJump.Unsubscribe(e.fsdJumpChan)
 		e.fsdJumpChan = nil
@@ -144,21 +259,103 @@ func (e *ExpeditionService) Stop() error {
 		e.watcher.StartJump.Unsubscribe(e.startJumpChan)
 		e.startJumpChan = nil
 	}
+	if e.carrierJumpChan != nil {
+		e.watcher.CarrierJump.Unsubscribe(e.carrierJumpChan)
+		e.carrierJumpChan = nil
+	}
+	if e.jetConeChan != nil {
+		e.watcher.JetConeBoost.Unsubscribe(e.jetConeChan)
+		e.jetConeChan = nil
+	}
+	if e.synthesisChan != nil {
+		e.watcher.Synthesis.Unsubscribe(e.synthesisChan)
+		e.synthesisChan = nil
+	}
+	if e.locationChan != nil {
+		e.watcher.Location.Unsubscribe(e.locationChan)
+		e.locationChan = nil
+	}
 	if e.fsdChargingChan != nil {
 		e.watcher.FsdCharging.Unsubscribe(e.fsdChargingChan)
 		e.fsdChargingChan = nil
 	}
+	if e.fuelScoopChan != nil {
+		e.watcher.FuelScoop.Unsubscribe(e.fuelScoopChan)
+		e.fuelScoopChan = nil
+	}
+	if e.loadoutChan != nil {
+		e.watcher.Loadout.Unsubscribe(e.loadoutChan)
+		e.loadoutChan = nil
+	}
+	if e.hullDamageChan != nil {
+		e.watcher.HullDamage.Unsubscribe(e.hullDamageChan)
+		e.hullDamageChan = nil
+	}
+	if e.afmuRepairsChan != nil {
+		e.watcher.AfmuRepairs.Unsubscribe(e.afmuRepairsChan)
+		e.afmuRepairsChan = nil
+	}
+	if e.repairChan != nil {
+		e.watcher.Repair.Unsubscribe(e.repairChan)
+		e.repairChan = nil
+	}
+	if e.scanChan != nil {
+		e.watcher.Scan.Unsubscribe(e.scanChan)
+		e.scanChan = nil
+	}
+	if e.fssDiscoveryScanChan != nil {
+		e.watcher.FSSDiscoveryScan.Unsubscribe(e.fssDiscoveryScanChan)
+		e.fssDiscoveryScanChan = nil
+	}
+	if e.fssAllBodiesFoundChan != nil {
+		e.watcher.FSSAllBodiesFound.Unsubscribe(e.fssAllBodiesFoundChan)
+		e.fssAllBodiesFoundChan = nil
+	}
+	if e.saaSignalsFoundChan != nil {
+		e.watcher.SAASignalsFound.Unsubscribe(e.saaSignalsFoundChan)
+		e.saaSignalsFoundChan = nil
+	}
+	e.mu.Lock()
+	if e.explorationSaveTimer != nil {
+		e.explorationSaveTimer.Stop()
+	}
+	if e.healthSaveTimer != nil {
+		e.healthSaveTimer.Stop()
+	}
+	e.mu.Unlock()
+	e.scoopMu.Lock()
+	if e.scoopSaveTimer != nil {
+		e.scoopSaveTimer.Stop()
+	}
+	e.scoopMu.Unlock()
 	e.stopChargingTimeout()
 	return nil
 }
 
 func (e *ExpeditionService) GetNextSystemName() *string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
 	if e.activeExpedition == nil || e.bakedRoute == nil {
 		return nil
 	}
+	if next := e.rejoinNextSystem(); next != nil {
+		return next
+	}
 	nextIndex := e.activeExpedition.CurrentBakedIndex + 1
 	if nextIndex >= len(e.bakedRoute.Jumps) {
 		return nil
 	}
 	return &e.bakedRoute.Jumps[nextIndex].SystemName
 }
+
+// deleteBakedRoute removes a baked route that has been replaced by a new bake
+func (e *ExpeditionService) deleteBakedRoute(id *string) {
+	if id == nil {
+		return
+	}
+	if err := models.DeleteRoute(*id); err != nil {
+		// The only side-effect is an unreachable route file
+		e.logger.Error(fmt.Sprintf("Failed to delete replaced baked route: %s", err.Error()))
+	}
+}
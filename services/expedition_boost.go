@@ -0,0 +1,100 @@
+package services
+
+import (
+	"ed-expedition/journal"
+	"ed-expedition/models"
+	"fmt"
+)
+
+// BoostAlert tells whether the ship has the boost the next jump on the route
+// was plotted with. Published after every jump, when a boost is picked up and
+// when the FSD starts charging.
+type BoostAlert struct {
+	Required  models.FSDBoost `json:"required"`
+	Available models.FSDBoost `json:"available"`
+	Ready     bool            `json:"ready"`
+	Message   string          `json:"message"`
+}
+
+func (e *ExpeditionService) handleJetConeBoost(event *journal.JetConeBoostEvent) {
+	if boost, ok := event.FSDBoost(); ok {
+		e.addBoost(boost)
+	}
+}
+
+func (e *ExpeditionService) handleSynthesis(event *journal.SynthesisEvent) {
+	if boost, ok := event.FSDBoost(); ok {
+		e.addBoost(boost)
+	}
+}
+
+func (e *ExpeditionService) addBoost(boost models.FSDBoost) {
+	if e.isOtherCommander(e.currentCommander()) {
+		return
+	}
+
+	e.boostMu.Lock()
+	if e.availableBoost.RangeMultiplier() < boost.RangeMultiplier() {
+		e.availableBoost = boost
+	}
+	e.boostMu.Unlock()
+
+	e.checkNextBoost()
+}
+
+// consumeBoost is called on every jump, the boost only lasts for one
+func (e *ExpeditionService) consumeBoost() {
+	e.boostMu.Lock()
+	defer e.boostMu.Unlock()
+	e.availableBoost = models.FSDBoostNone
+}
+
+func (e *ExpeditionService) checkNextBoost() {
+	if e.activeExpedition == nil || e.bakedRoute == nil || e.currentJump == nil || e.currentJump.BakedIndex == nil {
+		return
+	}
+	index := *e.currentJump.BakedIndex
+	if index >= len(e.bakedRoute.Jumps)-1 {
+		return
+	}
+
+	// The boost is planned on the system the jump leaves from
+	required := models.FSDBoostNone
+	if boost := e.bakedRoute.Jumps[index].FSDBoost; boost != nil {
+		required = *boost
+	}
+
+	e.boostMu.Lock()
+	available := e.availableBoost
+	e.boostMu.Unlock()
+
+	alert := &BoostAlert{
+		Required:  required,
+		Available: available,
+		Ready:     available.RangeMultiplier() >= required.RangeMultiplier(),
+	}
+	next := e.bakedRoute.Jumps[index+1].SystemName
+	if !alert.Ready {
+		alert.Message = fmt.Sprintf("The jump to %s needs %s", next, describeBoost(required))
+	}
+
+	e.logger.Trace(fmt.Sprintf("[ExpeditionService](Boost) next jump to %s requires %d, available %d", next, required, available))
+	e.BoostAlert.Publish(alert)
+}
+
+func describeBoost(boost models.FSDBoost) string {
+	switch boost {
+	case models.FSDBoostNeutron:
+		return "a neutron supercharge"
+	case models.FSDBoostInjectionBasic:
+		return "a basic FSD injection or better"
+	case models.FSDBoostInjectionStandard:
+		return "a standard FSD injection or better"
+	case models.FSDBoostInjectionPremium:
+		return "a premium FSD injection or a neutron supercharge"
+	case models.FSDBoostWhiteDwarf:
+		return "a white dwarf supercharge or a standard FSD injection"
+	default:
+		return "no boost"
+	}
+}
@@ -0,0 +1,183 @@
+package services
+
+import (
+	"ed-expedition/database"
+	"ed-expedition/journal"
+	"ed-expedition/lib/slice"
+	"ed-expedition/models"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// followBranch checks whether a jump that missed the next system on the baked
+// route went into an alternative link leaving from the current system. If so
+// the rest of the baked route is re-baked along that branch, so the jump is
+// matched as expected. Returns whether it did.
+func (e *ExpeditionService) followBranch(event *journal.FSDJumpEvent) bool {
+	expedition := e.activeExpedition
+	index := expedition.CurrentBakedIndex
+	if index < 0 || index >= len(e.bakedRoute.Jumps)-1 {
+		return false
+	}
+	if e.bakedRoute.Jumps[index+1].SystemID == event.SystemAddress {
+		return false
+	}
+	if !slices.ContainsFunc(expedition.Links, func(l models.Link) bool { return l.Alternative }) {
+		return false
+	}
+
+	routeById, err := loadRouteMap(expedition)
+	if err != nil {
+		e.logger.Error(fmt.Sprintf("[ExpeditionService](Branch) %s", err.Error()))
+		return false
+	}
+
+	currentSystem := e.bakedRoute.Jumps[index].SystemID
+	for _, link := range expedition.Links {
+		if !link.Alternative {
+			continue
+		}
+		from, ok := routeById[link.From.RouteID]
+		if !ok || link.From.JumpIndex >= len(from.Jumps) || from.Jumps[link.From.JumpIndex].SystemID != currentSystem {
+			continue
+		}
+		if _, ok := routeById[link.To.RouteID]; !ok {
+			continue
+		}
+
+		jumps, loopBackIndex := bakeJumps(expedition, routeById, &link.To, e.bakedRoute.Jumps[:index+1])
+		if len(jumps) < index+2 || jumps[index+1].SystemID != event.SystemAddress {
+			continue
+		}
+
+		if err := e.takeBranch(link, jumps, loopBackIndex, event.Timestamp); err != nil {
+			e.logger.Error(fmt.Sprintf("[ExpeditionService](Branch) Failed to follow branch: %s", err.Error()))
+			return false
+		}
+		return true
+	}
+
+	return false
+}
+
+// takeBranch replaces the baked route with jumps, which follow the branch
+// after the current system
+func (e *ExpeditionService) takeBranch(link models.Link, jumps []models.RouteJump, loopBackIndex int, timestamp time.Time) error {
+	expedition := e.activeExpedition
+	index := expedition.CurrentBakedIndex
+
+	expeditionSummary := slice.Find(
+		e.Index.Expeditions,
+		func(s models.ExpeditionSummary) bool { return s.ID == expedition.ID },
+	)
+	if expeditionSummary == nil {
+		return fmt.Errorf("Unable to find active expedition summary")
+	}
+
+	baked := newBakedRoute(expedition, jumps)
+
+	prevHistory := expedition.JumpHistory
+	prevBakedRouteID := expedition.BakedRouteID
+	prevLoopBackIndex := expedition.BakedLoopBackIndex
+	prevLaps := expedition.Laps
+	prevStats := expedition.Stats
+	prevBranches := expedition.Branches
+	prevLastUpdated := expedition.LastUpdated
+	prevSummaryLastUpdated := expeditionSummary.LastUpdated
+	undo := func() {
+		expedition.JumpHistory = prevHistory
+		expedition.BakedRouteID = prevBakedRouteID
+		expedition.BakedLoopBackIndex = prevLoopBackIndex
+		expedition.Laps = prevLaps
+		expedition.Stats = prevStats
+		expedition.Branches = prevBranches
+		expedition.LastUpdated = prevLastUpdated
+		expeditionSummary.LastUpdated = prevSummaryLastUpdated
+	}
+
+	expedition.JumpHistory = remapBranchHistory(expedition.JumpHistory, index, e.bakedRoute, jumps)
+	expedition.BakedRouteID = &baked.ID
+	expedition.BakedLoopBackIndex = nil
+	if loopBackIndex > -1 {
+		expedition.BakedLoopBackIndex = &loopBackIndex
+	}
+	// Laps ended on earlier laps of the replaced route are now found on the
+	// last system of the new one
+	if expedition.BakedLoopBackIndex != nil {
+		expedition.Laps = computeLaps(expedition, baked)
+	}
+	if expedition.Stats != nil {
+		expedition.Stats = computeExpeditionStats(expedition, baked)
+	}
+	expedition.Branches = append(slices.Clone(expedition.Branches), models.BranchTaken{
+		Timestamp:  timestamp,
+		LinkID:     link.ID,
+		BakedIndex: index,
+	})
+	expedition.LastUpdated = time.Now()
+	expeditionSummary.LastUpdated = expedition.LastUpdated
+
+	t := database.NewTransaction("ExpeditionService.takeBranch")
+
+	if err := models.TSaveRoute(t, baked); err != nil {
+		undo()
+		return fmt.Errorf("Failed to save baked route: %s", err.Error())
+	}
+
+	if err := models.TSaveExpedition(t, expedition); err != nil {
+		undo()
+		if rErr := t.Rewind(); rErr != nil {
+			e.logger.Error("[ExpeditionService] takeBranch transaction rewind failed after save expedition.")
+		}
+		return fmt.Errorf("Failed to save expedition: %s", err.Error())
+	}
+
+	if err := models.TSaveIndex(t, e.Index); err != nil {
+		undo()
+		if rErr := t.Rewind(); rErr != nil {
+			e.logger.Error("[ExpeditionService] takeBranch transaction rewind failed after save index.")
+		}
+		return fmt.Errorf("Failed to save index: %s", err.Error())
+	}
+
+	if err := t.Apply(); err != nil {
+		undo()
+		e.logger.Error("[ExpeditionService] takeBranch transaction failed to apply.")
+		return fmt.Errorf("Failed to follow branch: %s", err.Error())
+	}
+	e.deleteBakedRoute(prevBakedRouteID)
+
+	e.logger.Info(fmt.Sprintf("[ExpeditionService](Branch) Following link %s from %s", link.ID, jumps[index].SystemName))
+	e.bakedRoute = baked
+
+	return nil
+}
+
+// remapBranchHistory points the history entries past the branch, flown on
+// earlier laps of the replaced route, at the new route. Entries on its last
+// system ended a lap and stay on the last system, others move to the same
+// system on the branch or off route if the branch doesn't pass it.
+func remapBranchHistory(history []models.JumpHistoryEntry, branchIndex int, prev *models.Route, jumps []models.RouteJump) []models.JumpHistoryEntry {
+	prevLast := len(prev.Jumps) - 1
+	remapped := slices.Clone(history)
+	for i := range remapped {
+		entry := &remapped[i]
+		if entry.BakedIndex == nil || *entry.BakedIndex <= branchIndex {
+			continue
+		}
+		if *entry.BakedIndex == prevLast {
+			last := len(jumps) - 1
+			entry.BakedIndex = &last
+			continue
+		}
+		offset := slices.IndexFunc(jumps[branchIndex+1:], func(jump models.RouteJump) bool { return jump.SystemID == entry.SystemID })
+		if offset == -1 {
+			entry.BakedIndex = nil
+			continue
+		}
+		index := branchIndex + 1 + offset
+		entry.BakedIndex = &index
+	}
+	return remapped
+}
@@ -0,0 +1,245 @@
+package services
+
+import (
+	"ed-expedition/database"
+	"ed-expedition/models"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Version of the bundle format written by ExportExpedition
+const expeditionBundleVersion = 1
+
+// ExpeditionBundle is an expedition with every route it references, for
+// sharing between installs. IDs are rewritten on export so the bundle does not
+// depend on the database it came from.
+type ExpeditionBundle struct {
+	Version    int                `json:"version"`
+	ExportedAt time.Time          `json:"exported_at"`
+	Expedition *models.Expedition `json:"expedition"`
+	// Kept raw so routes of an older version are migrated on import
+	Routes     []json.RawMessage `json:"routes"`
+	BakedRoute json.RawMessage   `json:"baked_route,omitempty"`
+}
+
+// ExportExpedition writes the expedition, its routes and its baked route to a
+// single JSON bundle
+func (e *ExpeditionService) ExportExpedition(expeditionId string) ([]byte, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	source, err := e.loadExpedition(expeditionId)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to load expedition: %s", err.Error())
+	}
+
+	routeIDs := make(map[string]string, len(source.Routes))
+	bundle := &ExpeditionBundle{
+		Version:    expeditionBundleVersion,
+		ExportedAt: time.Now(),
+		Routes:     make([]json.RawMessage, 0, len(source.Routes)),
+	}
+
+	for i, routeId := range source.Routes {
+		route, err := models.LoadRoute(routeId)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to load route '%s': %s", routeId, err.Error())
+		}
+		exported := *route
+		exported.ID = fmt.Sprintf("route-%d", i+1)
+		routeIDs[routeId] = exported.ID
+
+		data, err := json.Marshal(&exported)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to encode route '%s': %s", routeId, err.Error())
+		}
+		bundle.Routes = append(bundle.Routes, data)
+	}
+
+	expedition := *source
+	expedition.ID = "expedition"
+	// Not the business of whoever the bundle is shared with
+	expedition.CommanderFID = ""
+	expedition.Routes = make([]string, 0, len(source.Routes))
+	for _, routeId := range source.Routes {
+		expedition.Routes = append(expedition.Routes, routeIDs[routeId])
+	}
+
+	if source.Start != nil {
+		expedition.Start = &models.RoutePosition{
+			RouteID:   routeIDs[source.Start.RouteID],
+			JumpIndex: source.Start.JumpIndex,
+		}
+	}
+
+	expedition.Links = make([]models.Link, 0, len(source.Links))
+	for i, link := range source.Links {
+		expedition.Links = append(expedition.Links, models.Link{
+			ID:          fmt.Sprintf("link-%d", i+1),
+			From:        models.RoutePosition{RouteID: routeIDs[link.From.RouteID], JumpIndex: link.From.JumpIndex},
+			To:          models.RoutePosition{RouteID: routeIDs[link.To.RouteID], JumpIndex: link.To.JumpIndex},
+			Alternative: link.Alternative,
+		})
+	}
+
+	if source.BakedRouteID != nil {
+		baked := e.bakedRoute
+		if source != e.activeExpedition {
+			baked, err = source.LoadBaked()
+			if err != nil {
+				return nil, fmt.Errorf("Failed to load baked route: %s", err.Error())
+			}
+		}
+		exported := *baked
+		exported.ID = "baked"
+		bundle.BakedRoute, err = json.Marshal(&exported)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to encode baked route: %s", err.Error())
+		}
+		expedition.BakedRouteID = &exported.ID
+	}
+
+	bundle.Expedition = &expedition
+
+	return json.MarshalIndent(bundle, "", "  ")
+}
+
+// ImportExpedition adds the expedition in a bundle written by ExportExpedition
+// as a new planned expedition, and returns its ID. Everything gets a new ID.
+// The jump history and baked route of the bundle are dropped, a new one is
+// baked when the expedition is started.
+func (e *ExpeditionService) ImportExpedition(data []byte) (string, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var bundle ExpeditionBundle
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		return "", fmt.Errorf("Failed to read expedition bundle: %s", err.Error())
+	}
+	if bundle.Version < 1 || bundle.Version > expeditionBundleVersion {
+		return "", fmt.Errorf("Unsupported expedition bundle version %d", bundle.Version)
+	}
+	if bundle.Expedition == nil {
+		return "", errors.New("The bundle does not contain an expedition")
+	}
+
+	routes := make(map[string]*models.Route, len(bundle.Routes))
+	routeIDs := make(map[string]string, len(bundle.Routes))
+	imported := make([]*models.Route, 0, len(bundle.Routes))
+	for i, raw := range bundle.Routes {
+		route, err := models.ParseRoute(raw)
+		if err != nil {
+			return "", fmt.Errorf("Failed to read route %d: %s", i+1, err.Error())
+		}
+		if _, ok := routeIDs[route.ID]; ok {
+			return "", fmt.Errorf("The bundle contains route '%s' more than once", route.ID)
+		}
+		newId := uuid.New().String()
+		routeIDs[route.ID] = newId
+		route.ID = newId
+		routes[newId] = route
+		imported = append(imported, route)
+	}
+
+	source := bundle.Expedition
+	now := time.Now()
+	expedition := &models.Expedition{
+		ID:                uuid.New().String(),
+		Name:              source.Name,
+		CreatedAt:         now,
+		LastUpdated:       now,
+		Status:            models.StatusPlanned,
+		CountCarrierJumps: source.CountCarrierJumps,
+		MaxLaps:           source.MaxLaps,
+		Routes:            make([]string, 0, len(source.Routes)),
+		Links:             []models.Link{},
+		JumpHistory:       []models.JumpHistoryEntry{},
+	}
+
+	for _, routeId := range source.Routes {
+		newId, ok := routeIDs[routeId]
+		if !ok {
+			return "", fmt.Errorf("Route '%s' is missing from the bundle", routeId)
+		}
+		expedition.Routes = append(expedition.Routes, newId)
+	}
+
+	if source.Start != nil {
+		newId, ok := routeIDs[source.Start.RouteID]
+		if !ok || !expedition.HasRoute(newId) {
+			return "", errors.New("The start of the expedition is not on one of its routes")
+		}
+		if source.Start.JumpIndex < 0 || source.Start.JumpIndex >= len(routes[newId].Jumps) {
+			return "", errors.New("The start of the expedition is out of bounds")
+		}
+		expedition.Start = &models.RoutePosition{RouteID: newId, JumpIndex: source.Start.JumpIndex}
+	}
+
+	loadRoute := func(id string) (*models.Route, error) {
+		route, ok := routes[id]
+		if !ok {
+			return nil, fmt.Errorf("route '%s' is not in the bundle", id)
+		}
+		return route, nil
+	}
+	for _, link := range source.Links {
+		link := models.Link{
+			ID:          uuid.New().String(),
+			From:        models.RoutePosition{RouteID: routeIDs[link.From.RouteID], JumpIndex: link.From.JumpIndex},
+			To:          models.RoutePosition{RouteID: routeIDs[link.To.RouteID], JumpIndex: link.To.JumpIndex},
+			Alternative: link.Alternative,
+		}
+		if err := validateLink(expedition, link, loadRoute); err != nil {
+			return "", fmt.Errorf("Invalid link in bundle: %s", err.Error())
+		}
+		expedition.Links = append(expedition.Links, link)
+	}
+
+	summary := models.ExpeditionSummary{
+		ID:          expedition.ID,
+		Name:        expedition.Name,
+		Status:      models.StatusPlanned,
+		CreatedAt:   now,
+		LastUpdated: now,
+	}
+
+	t := database.NewTransaction("ExpeditionService.ImportExpedition")
+
+	for _, route := range imported {
+		if err := models.TSaveRoute(t, route); err != nil {
+			if rErr := t.Rewind(); rErr != nil {
+				e.logger.Error("[ExpeditionService] ImportExpedition transaction rewind failed after save route.")
+			}
+			return "", fmt.Errorf("Failed to save route: %s", err.Error())
+		}
+	}
+
+	if err := models.TSaveExpedition(t, expedition); err != nil {
+		if rErr := t.Rewind(); rErr != nil {
+			e.logger.Error("[ExpeditionService] ImportExpedition transaction rewind failed after save expedition.")
+		}
+		return "", fmt.Errorf("Failed to save expedition: %s", err.Error())
+	}
+
+	e.Index.Expeditions = append(e.Index.Expeditions, summary)
+
+	if err := models.TSaveIndex(t, e.Index); err != nil {
+		e.Index.Expeditions = e.Index.Expeditions[:len(e.Index.Expeditions)-1]
+		if rErr := t.Rewind(); rErr != nil {
+			e.logger.Error("[ExpeditionService] ImportExpedition transaction rewind failed after save index.")
+		}
+		return "", fmt.Errorf("Failed to save index: %s", err.Error())
+	}
+
+	if err := t.Apply(); err != nil {
+		e.Index.Expeditions = e.Index.Expeditions[:len(e.Index.Expeditions)-1]
+		e.logger.Error(fmt.Sprintf("[ExpeditionService] ImportExpedition transaction failed to apply: %v", err))
+		return "", fmt.Errorf("Failed to import expedition: %s", err.Error())
+	}
+
+	return expedition.ID, nil
+}
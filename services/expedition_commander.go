@@ -0,0 +1,116 @@
+package services
+
+import (
+	"ed-expedition/journal"
+	"ed-expedition/models"
+	"fmt"
+	"maps"
+)
+
+// SetCommander sets the commander currently logged in, until the journal says
+// otherwise. Call before Start with the last known commander.
+func (e *ExpeditionService) SetCommander(fid string) {
+	e.commanderMu.Lock()
+	defer e.commanderMu.Unlock()
+	e.commanderFID = fid
+}
+
+func (e *ExpeditionService) currentCommander() string {
+	e.commanderMu.Lock()
+	defer e.commanderMu.Unlock()
+	return e.commanderFID
+}
+
+func (e *ExpeditionService) handleCommander(event *journal.CommanderEvent) {
+	e.logger.Trace(fmt.Sprintf("[ExpeditionService](Commander) commander is now %s (%s)", event.Name, event.FID))
+	e.SetCommander(event.FID)
+	if err := e.switchCommander(event.FID); err != nil {
+		e.logger.Error(fmt.Sprintf("[ExpeditionService](Commander) Failed to switch to the expedition of %s: %s", event.FID, err.Error()))
+	}
+}
+
+// switchCommander parks the active expedition of the previous commander and
+// makes the one parked for fid active, if there is one. An expedition started
+// without a known commander stays active for whoever logs in.
+func (e *ExpeditionService) switchCommander(fid string) error {
+	if fid == "" {
+		return nil
+	}
+	active := e.activeExpedition
+	if active != nil && (active.CommanderFID == "" || active.CommanderFID == fid) {
+		return nil
+	}
+	parkedId, hasParked := e.Index.ParkedExpeditions[fid]
+	if active == nil && !hasParked {
+		return nil
+	}
+
+	var expedition *models.Expedition
+	var route *models.Route
+	if hasParked {
+		var err error
+		expedition, err = models.LoadExpedition(parkedId)
+		if err != nil {
+			return fmt.Errorf("Failed to load expedition: %s", err.Error())
+		}
+		route, err = expedition.LoadBaked()
+		if err != nil {
+			return fmt.Errorf("Failed to load baked route: %s", err.Error())
+		}
+	}
+
+	prevActiveExpeditionId := e.Index.ActiveExpeditionID
+	prevParked := e.Index.ParkedExpeditions
+	undo := func() {
+		e.Index.ActiveExpeditionID = prevActiveExpeditionId
+		e.Index.ParkedExpeditions = prevParked
+	}
+
+	parked := maps.Clone(e.Index.ParkedExpeditions)
+	if parked == nil {
+		parked = map[string]string{}
+	}
+	if active != nil {
+		parked[active.CommanderFID] = active.ID
+	}
+	delete(parked, fid)
+	e.Index.ParkedExpeditions = parked
+	e.Index.ActiveExpeditionID = nil
+	if expedition != nil {
+		e.Index.ActiveExpeditionID = &expedition.ID
+	}
+
+	if err := models.SaveIndex(e.Index); err != nil {
+		undo()
+		return fmt.Errorf("Failed to save index: %s", err.Error())
+	}
+
+	e.activeExpedition = expedition
+	e.bakedRoute = route
+	e.currentJump = nil
+	e.pendingRebuild = nil
+
+	e.rejoinMu.Lock()
+	e.rejoinGeneration++
+	e.rejoin = nil
+	e.rejoinMu.Unlock()
+
+	if expedition != nil {
+		e.logger.Info(fmt.Sprintf("[ExpeditionService](Commander) Switched to expedition %s of %s", expedition.Name, fid))
+		e.publishETA()
+	} else {
+		e.logger.Info(fmt.Sprintf("[ExpeditionService](Commander) Parked expedition %s, %s has none active", active.Name, fid))
+	}
+	return nil
+}
+
+// isOtherCommander reports whether an event from the given commander should be
+// ignored because the active expedition belongs to someone else. When either
+// side is unknown the event is accepted, as it was before commanders were
+// tracked.
+func (e *ExpeditionService) isOtherCommander(fid string) bool {
+	if e.activeExpedition == nil || e.activeExpedition.CommanderFID == "" || fid == "" {
+		return false
+	}
+	return fid != e.activeExpedition.CommanderFID
+}
@@ -0,0 +1,215 @@
+package services
+
+import (
+	"ed-expedition/database"
+	"ed-expedition/lib/slice"
+	"ed-expedition/models"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// SetCurrentBakedIndex moves the active expedition to the system at index on
+// the baked route, for when a jump was matched wrong or the commander skipped
+// ahead. The jump history is left as it is.
+func (e *ExpeditionService) SetCurrentBakedIndex(expeditionId string, index int, note string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.applyCorrection(expeditionId, true, func(expedition *models.Expedition, route *models.Route) (*models.Correction, error) {
+		if index < 0 || index >= len(route.Jumps) {
+			return nil, errors.New("The index is not on the baked route")
+		}
+		expedition.CurrentBakedIndex = index
+		return &models.Correction{Kind: models.CorrectionSetPosition, Note: note}, nil
+	})
+}
+
+// InsertSkippedJumps adds synthetic jumps for the systems on the baked route
+// after the current one up to and including the one at index, and moves the
+// expedition there. They are timestamped now, so the journal events still to
+// come are not taken for ones already recorded.
+func (e *ExpeditionService) InsertSkippedJumps(expeditionId string, index int, note string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.applyCorrection(expeditionId, true, func(expedition *models.Expedition, route *models.Route) (*models.Correction, error) {
+		if index <= expedition.CurrentBakedIndex || index >= len(route.Jumps) {
+			return nil, errors.New("The index must be ahead of the current system on the baked route")
+		}
+
+		// Never before the last entry, the history stays in order
+		timestamp := time.Now()
+		if len(expedition.JumpHistory) > 0 {
+			if last := expedition.JumpHistory[len(expedition.JumpHistory)-1].Timestamp; timestamp.Before(last) {
+				timestamp = last
+			}
+		}
+
+		from := expedition.CurrentBakedIndex + 1
+		for i := from; i <= index; i++ {
+			expedition.JumpHistory = append(expedition.JumpHistory, syntheticJump(route, i, timestamp))
+		}
+		expedition.CurrentBakedIndex = index
+
+		return &models.Correction{Kind: models.CorrectionInsertSkipped, Inserted: index - from + 1, Note: note}, nil
+	})
+}
+
+// DeleteHistoryEntry removes the entry at index from the jump history. The
+// position on the route is not changed, see SetCurrentBakedIndex.
+func (e *ExpeditionService) DeleteHistoryEntry(expeditionId string, index int, note string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.applyCorrection(expeditionId, false, func(expedition *models.Expedition, route *models.Route) (*models.Correction, error) {
+		if index < 0 || index >= len(expedition.JumpHistory) {
+			return nil, errors.New("There is no such entry in the jump history")
+		}
+		entry := expedition.JumpHistory[index]
+		expedition.JumpHistory = slices.Delete(expedition.JumpHistory, index, index+1)
+		return &models.Correction{Kind: models.CorrectionDeleteEntry, HistoryIndex: &index, Entry: &entry, Note: note}, nil
+	})
+}
+
+// MarkHistoryEntry marks the entry at index as erroneous, or clears the mark.
+// Marked entries stay in the history but are left out of the statistics.
+func (e *ExpeditionService) MarkHistoryEntry(expeditionId string, index int, erroneous bool, note string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.applyCorrection(expeditionId, false, func(expedition *models.Expedition, route *models.Route) (*models.Correction, error) {
+		if index < 0 || index >= len(expedition.JumpHistory) {
+			return nil, errors.New("There is no such entry in the jump history")
+		}
+		entry := expedition.JumpHistory[index]
+		expedition.JumpHistory[index].Erroneous = erroneous
+		return &models.Correction{Kind: models.CorrectionMarkEntry, HistoryIndex: &index, Entry: &entry, Note: note}, nil
+	})
+}
+
+// applyCorrection runs correct on a started expedition and saves the result
+// with the correction added to its audit trail. The jump history handed to
+// correct is a copy, so nothing changes if it fails.
+func (e *ExpeditionService) applyCorrection(
+	expeditionId string,
+	activeOnly bool,
+	correct func(expedition *models.Expedition, route *models.Route) (*models.Correction, error),
+) error {
+	expedition, err := e.loadExpedition(expeditionId)
+	if err != nil {
+		return fmt.Errorf("Failed to load expedition: %s", err.Error())
+	}
+	if expedition.BakedRouteID == nil {
+		return errors.New("The expedition has not been started, there is nothing to correct")
+	}
+	isActive := expedition == e.activeExpedition
+	if activeOnly && !isActive {
+		return errors.New("Only the position of the active expedition can be corrected")
+	}
+
+	route := e.bakedRoute
+	if !isActive {
+		route, err = expedition.LoadBaked()
+		if err != nil {
+			return fmt.Errorf("Failed to load baked route: %s", err.Error())
+		}
+	}
+
+	expeditionSummary := slice.Find(
+		e.Index.Expeditions,
+		func(exp models.ExpeditionSummary) bool { return exp.ID == expedition.ID },
+	)
+	if expeditionSummary == nil {
+		return errors.New("Failed to find this expedition in the index")
+	}
+
+	prevHistory := expedition.JumpHistory
+	prevBakedIndex := expedition.CurrentBakedIndex
+	prevCorrections := expedition.Corrections
+	prevStats := expedition.Stats
+	prevLaps := expedition.Laps
+	prevLastUpdated := expedition.LastUpdated
+	undo := func() {
+		expedition.JumpHistory = prevHistory
+		expedition.Laps = prevLaps
+		expedition.CurrentBakedIndex = prevBakedIndex
+		expedition.Corrections = prevCorrections
+		expedition.Stats = prevStats
+		expedition.LastUpdated = prevLastUpdated
+		expeditionSummary.LastUpdated = prevLastUpdated
+	}
+
+	expedition.JumpHistory = slices.Clone(expedition.JumpHistory)
+	correction, err := correct(expedition, route)
+	if err != nil {
+		undo()
+		return err
+	}
+
+	if expedition.BakedLoopBackIndex != nil {
+		expedition.Laps = computeLaps(expedition, route)
+		wrapLoop(expedition, route)
+	}
+
+	now := time.Now()
+	correction.Timestamp = now
+	correction.PrevBakedIndex = prevBakedIndex
+	correction.BakedIndex = expedition.CurrentBakedIndex
+	expedition.Corrections = append(slices.Clone(expedition.Corrections), *correction)
+	expedition.LastUpdated = now
+	expeditionSummary.LastUpdated = now
+	if expedition.Stats != nil {
+		expedition.Stats = computeExpeditionStats(expedition, route)
+	}
+
+	t := database.NewTransaction("ExpeditionService.applyCorrection")
+
+	if err := models.TSaveExpedition(t, expedition); err != nil {
+		undo()
+		return fmt.Errorf("Failed to save expedition: %s", err.Error())
+	}
+
+	if err := models.TSaveIndex(t, e.Index); err != nil {
+		undo()
+		if rErr := t.Rewind(); rErr != nil {
+			e.logger.Error("[ExpeditionService] applyCorrection transaction rewind failed.")
+		}
+		return fmt.Errorf("Failed to save index: %s", err.Error())
+	}
+
+	if err := t.Apply(); err != nil {
+		undo()
+		e.logger.Error("[ExpeditionService] applyCorrection transaction failed to apply.")
+		return fmt.Errorf("Failed to correct expedition: %s", err.Error())
+	}
+
+	e.logger.Info(fmt.Sprintf("[ExpeditionService](Correction) %s, baked index %d -> %d", correction.Kind, prevBakedIndex, expedition.CurrentBakedIndex))
+
+	if !isActive {
+		return nil
+	}
+
+	// The history was copied, point at the entry in the new one
+	e.currentJump = nil
+	if len(expedition.JumpHistory) > 0 {
+		e.currentJump = &expedition.JumpHistory[len(expedition.JumpHistory)-1]
+		e.CurrentJump.Publish(e.currentJump)
+	}
+
+	e.rejoinMu.Lock()
+	e.rejoinGeneration++
+	e.rejoin = nil
+	e.rejoinMu.Unlock()
+
+	if atRouteEnd(expedition, route) {
+		return e.completeActiveExpedition()
+	}
+
+	e.checkNextBoost()
+	e.checkFSDHealth()
+	e.publishETA()
+
+	return nil
+}
@@ -0,0 +1,193 @@
+package services
+
+import (
+	"ed-expedition/journal"
+	"ed-expedition/models"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Hull damage comes in bursts during a fight, save once it settles
+const healthSaveDelay = 2 * time.Second
+
+// DamageAlert tells whether the FSD is healthy enough for the next neutron
+// jump on the route. Published after every jump and when the health of the
+// ship changes.
+type DamageAlert struct {
+	FSDHealth  float64  `json:"fsdHealth"`
+	HullHealth *float64 `json:"hullHealth,omitempty"`
+	Threshold  float64  `json:"threshold"`
+	// Jumps until the next planned neutron supercharge, nil without one
+	NeutronJumpsAway *int   `json:"neutronJumpsAway,omitempty"`
+	Ok               bool   `json:"ok"`
+	Message          string `json:"message"`
+}
+
+// SetFSDHealthThreshold sets the FSD health, from 0 to 1, below which a
+// neutron jump on the route raises an alert
+func (e *ExpeditionService) SetFSDHealthThreshold(threshold float64) {
+	e.healthMu.Lock()
+	defer e.healthMu.Unlock()
+	e.fsdHealthThreshold = threshold
+}
+
+func (e *ExpeditionService) handleLoadout(event *journal.LoadoutEvent) {
+	if e.isOtherCommander(event.CommanderFID) {
+		return
+	}
+
+	hull := event.HullHealth
+	var fsd *float64
+	for _, module := range event.Modules {
+		if module.Slot == "FrameShiftDrive" {
+			health := module.Health
+			fsd = &health
+			break
+		}
+	}
+
+	e.updateHealth(func(health *models.ShipHealth) {
+		health.Hull = &hull
+		health.FSD = fsd
+	})
+}
+
+func (e *ExpeditionService) handleHullDamage(event *journal.HullDamageEvent) {
+	if e.isOtherCommander(event.CommanderFID) || !event.PlayerPilot || event.Fighter {
+		return
+	}
+	hull := event.Health
+	e.updateHealth(func(health *models.ShipHealth) {
+		health.Hull = &hull
+	})
+}
+
+func (e *ExpeditionService) handleAfmuRepairs(event *journal.AfmuRepairsEvent) {
+	if e.isOtherCommander(event.CommanderFID) || !journal.IsFSDModule(event.Module) {
+		return
+	}
+	fsd := event.Health
+	e.updateHealth(func(health *models.ShipHealth) {
+		health.FSD = &fsd
+	})
+}
+
+func (e *ExpeditionService) handleRepair(event *journal.RepairEvent) {
+	if e.isOtherCommander(event.CommanderFID) {
+		return
+	}
+
+	repairedHull, repairedFSD := false, false
+	for _, item := range event.Repaired() {
+		switch {
+		case strings.EqualFold(item, "All"):
+			repairedHull, repairedFSD = true, true
+		case strings.EqualFold(item, "Hull"):
+			repairedHull = true
+		case journal.IsFSDModule(item):
+			repairedFSD = true
+		}
+	}
+	if !repairedHull && !repairedFSD {
+		return
+	}
+
+	e.updateHealth(func(health *models.ShipHealth) {
+		full := 1.0
+		if repairedHull {
+			health.Hull = &full
+		}
+		if repairedFSD {
+			health.FSD = &full
+		}
+	})
+}
+
+// updateHealth applies update to the known ship health, records it on the
+// current jump and checks the next neutron jump
+func (e *ExpeditionService) updateHealth(update func(health *models.ShipHealth)) {
+	e.healthMu.Lock()
+	update(&e.shipHealth)
+	snapshot := e.shipHealth.Clone()
+	e.healthMu.Unlock()
+
+	if e.activeExpedition != nil && e.currentJump != nil {
+		e.currentJump.Health = snapshot
+		e.CurrentJump.Publish(e.currentJump)
+		e.healthUpdated()
+	}
+
+	e.checkFSDHealth()
+}
+
+func (e *ExpeditionService) healthUpdated() {
+	if e.healthSaveTimer != nil {
+		e.healthSaveTimer.Stop()
+	}
+	e.healthSaveTimer = time.AfterFunc(healthSaveDelay, func() {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+
+		if e.activeExpedition == nil {
+			return
+		}
+
+		if err := models.SaveExpedition(e.activeExpedition); err != nil {
+			e.logger.Error(fmt.Sprintf("Failed to save expedition after ship health change: %s", err.Error()))
+		}
+	})
+}
+
+// healthSnapshot returns the known ship health, nil if nothing is known
+func (e *ExpeditionService) healthSnapshot() *models.ShipHealth {
+	e.healthMu.Lock()
+	defer e.healthMu.Unlock()
+	if e.shipHealth.Hull == nil && e.shipHealth.FSD == nil {
+		return nil
+	}
+	return e.shipHealth.Clone()
+}
+
+func (e *ExpeditionService) checkFSDHealth() {
+	if e.activeExpedition == nil || e.bakedRoute == nil || e.currentJump == nil || e.currentJump.BakedIndex == nil {
+		return
+	}
+
+	e.healthMu.Lock()
+	health := e.shipHealth.Clone()
+	threshold := e.fsdHealthThreshold
+	e.healthMu.Unlock()
+	if health.FSD == nil {
+		return
+	}
+
+	alert := &DamageAlert{
+		FSDHealth:  *health.FSD,
+		HullHealth: health.Hull,
+		Threshold:  threshold,
+		Ok:         true,
+	}
+
+	// The supercharge is planned on the system the jump leaves from
+	index := *e.currentJump.BakedIndex
+	for i := index; i < len(e.bakedRoute.Jumps)-1; i++ {
+		boost := e.bakedRoute.Jumps[i].FSDBoost
+		if boost == nil || *boost != models.FSDBoostNeutron {
+			continue
+		}
+		jumpsAway := i - index
+		alert.NeutronJumpsAway = &jumpsAway
+		if alert.FSDHealth < threshold {
+			alert.Ok = false
+			alert.Message = fmt.Sprintf(
+				"FSD integrity is at %.0f%%, repair it before supercharging at %s",
+				alert.FSDHealth*100, e.bakedRoute.Jumps[i].SystemName,
+			)
+		}
+		break
+	}
+
+	e.logger.Trace(fmt.Sprintf("[ExpeditionService](Damage) FSD health %.2f, threshold %.2f, ok %t", alert.FSDHealth, threshold, alert.Ok))
+	e.DamageAlert.Publish(alert)
+}
@@ -0,0 +1,260 @@
+package services
+
+import (
+	"ed-expedition/database"
+	"ed-expedition/journal"
+	"ed-expedition/lib/slice"
+	"ed-expedition/models"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// handleLocation records a death entry when the commander respawns. Other
+// locations are of no interest, jumps are tracked from FSDJump.
+func (e *ExpeditionService) handleLocation(event *journal.LocationEvent) {
+	if event.Died == nil || e.activeExpedition == nil {
+		return
+	}
+	if e.isOtherCommander(event.CommanderFID) {
+		e.logger.Trace(fmt.Sprintf("[ExpeditionService](Death) death of other commander %s, ignoring", event.CommanderFID))
+		return
+	}
+	jumpHistory := e.activeExpedition.JumpHistory
+	if len(jumpHistory) > 0 && !jumpHistory[len(jumpHistory)-1].Timestamp.Before(event.Timestamp) {
+		return
+	}
+	e.logger.Info(fmt.Sprintf("[ExpeditionService](Death) Commander died, respawned in %s", event.StarSystem))
+
+	entry := deathEntry(jumpHistory, event)
+
+	e.activeExpedition.JumpHistory = append(e.activeExpedition.JumpHistory, entry)
+	e.activeExpedition.LastUpdated = time.Now()
+	e.currentJump = &e.activeExpedition.JumpHistory[len(e.activeExpedition.JumpHistory)-1]
+	e.saveJump(&entry)
+}
+
+func deathEntry(jumpHistory []models.JumpHistoryEntry, event *journal.LocationEvent) models.JumpHistoryEntry {
+	death := &models.DeathRecord{
+		DiedAt:   event.Died.Timestamp,
+		KilledBy: event.Died.KillerName,
+	}
+	if death.KilledBy == "" && len(event.Died.Killers) > 0 {
+		names := make([]string, len(event.Died.Killers))
+		for i, killer := range event.Died.Killers {
+			names[i] = killer.Name
+		}
+		death.KilledBy = strings.Join(names, ", ")
+	}
+	if event.Resurrect != nil {
+		death.Resurrect = event.Resurrect.Option
+	}
+	if len(jumpHistory) > 0 {
+		last := jumpHistory[len(jumpHistory)-1]
+		death.SystemName = last.SystemName
+		death.SystemID = last.SystemID
+	}
+
+	return models.JumpHistoryEntry{
+		Timestamp:  event.Timestamp,
+		SystemName: event.StarSystem,
+		SystemID:   event.SystemAddress,
+
+		Expected:      false,
+		Synthetic:     false,
+		OffExpedition: true,
+		Death:         death,
+	}
+}
+
+// RewindTarget returns the system the commander respawned in and the last
+// system they visited on the baked route, the two ends of a rewind route.
+func (e *ExpeditionService) RewindTarget() (from *models.JumpHistoryEntry, to *models.RouteJump, err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	from, to, err = e.rewindTarget()
+	if err != nil {
+		return nil, nil, err
+	}
+	// Copies, the history keeps changing once the lock is released
+	fromCopy, toCopy := *from, *to
+	return &fromCopy, &toCopy, nil
+}
+
+func (e *ExpeditionService) rewindTarget() (from *models.JumpHistoryEntry, to *models.RouteJump, err error) {
+	if e.activeExpedition == nil || e.bakedRoute == nil {
+		return nil, nil, errors.New("There is no active expedition")
+	}
+
+	history := e.activeExpedition.JumpHistory
+	if len(history) == 0 || history[len(history)-1].Death == nil {
+		return nil, nil, errors.New("The commander has not died since the last jump")
+	}
+	if history[len(history)-1].BakedIndex != nil {
+		return nil, nil, errors.New("The expedition has already been rewound")
+	}
+	if e.activeExpedition.CurrentBakedIndex < 0 {
+		return nil, nil, errors.New("No system on the route has been visited yet")
+	}
+
+	from = &history[len(history)-1]
+	to = &e.bakedRoute.Jumps[e.activeExpedition.CurrentBakedIndex]
+	if from.SystemID == to.SystemID {
+		return nil, nil, errors.New("The commander respawned on the route, no need to rewind")
+	}
+	return from, to, nil
+}
+
+// SpliceRewindRoute inserts a route from the respawn system back to the last
+// visited system into the baked route, right after that system. The commander
+// continues from the start of the rewind route. The rewind route only lives on
+// in the baked route, it is not one of the planned routes.
+func (e *ExpeditionService) SpliceRewindRoute(route *models.Route) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	from, to, err := e.rewindTarget()
+	if err != nil {
+		return err
+	}
+	if len(route.Jumps) < 2 {
+		return errors.New("The rewind route needs at least two jumps")
+	}
+	if route.Jumps[0].SystemID != from.SystemID {
+		return fmt.Errorf("The rewind route must start in %s", from.SystemName)
+	}
+	if route.Jumps[len(route.Jumps)-1].SystemID != to.SystemID {
+		return fmt.Errorf("The rewind route must end in %s", to.SystemName)
+	}
+
+	expedition := e.activeExpedition
+	expeditionSummary := slice.Find(
+		e.Index.Expeditions,
+		func(s models.ExpeditionSummary) bool { return s.ID == expedition.ID },
+	)
+	if expeditionSummary == nil {
+		return errors.New("Unable to find active expedition summary")
+	}
+
+	baked := spliceRoute(e.bakedRoute, expedition.CurrentBakedIndex, route)
+	shift := len(route.Jumps)
+	spliceAt := expedition.CurrentBakedIndex
+
+	prevHistory := expedition.JumpHistory
+	prevBakedRouteID := expedition.BakedRouteID
+	prevBakedIndex := expedition.CurrentBakedIndex
+	prevLoopBackIndex := expedition.BakedLoopBackIndex
+	prevLaps := expedition.Laps
+	prevStats := expedition.Stats
+	prevLastUpdated := expedition.LastUpdated
+	prevSummaryLastUpdated := expeditionSummary.LastUpdated
+	undo := func() {
+		expedition.JumpHistory = prevHistory
+		expedition.BakedRouteID = prevBakedRouteID
+		expedition.CurrentBakedIndex = prevBakedIndex
+		expedition.BakedLoopBackIndex = prevLoopBackIndex
+		expedition.Laps = prevLaps
+		expedition.Stats = prevStats
+		expedition.LastUpdated = prevLastUpdated
+		expeditionSummary.LastUpdated = prevSummaryLastUpdated
+	}
+
+	history := slices.Clone(expedition.JumpHistory)
+	for i := range history {
+		if history[i].BakedIndex != nil && *history[i].BakedIndex > spliceAt {
+			shifted := *history[i].BakedIndex + shift
+			history[i].BakedIndex = &shifted
+		}
+	}
+	respawnIndex := spliceAt + 1
+	history[len(history)-1].BakedIndex = &respawnIndex
+
+	expedition.JumpHistory = history
+	expedition.BakedRouteID = &baked.ID
+	expedition.CurrentBakedIndex = respawnIndex
+	if prevLoopBackIndex != nil && *prevLoopBackIndex > spliceAt {
+		loopBackIndex := *prevLoopBackIndex + shift
+		expedition.BakedLoopBackIndex = &loopBackIndex
+	}
+	// Lap ends are found on the last system, which moved with the splice
+	if expedition.BakedLoopBackIndex != nil {
+		expedition.Laps = computeLaps(expedition, baked)
+		wrapLoop(expedition, baked)
+	}
+	if expedition.Stats != nil {
+		expedition.Stats = computeExpeditionStats(expedition, baked)
+	}
+	expedition.LastUpdated = time.Now()
+	expeditionSummary.LastUpdated = expedition.LastUpdated
+
+	t := database.NewTransaction("ExpeditionService.SpliceRewindRoute")
+
+	if err := models.TSaveRoute(t, baked); err != nil {
+		undo()
+		return fmt.Errorf("Failed to save baked route: %s", err.Error())
+	}
+
+	if err := models.TSaveExpedition(t, expedition); err != nil {
+		undo()
+		if rErr := t.Rewind(); rErr != nil {
+			e.logger.Error("[ExpeditionService] SpliceRewindRoute transaction rewind failed after save expedition.")
+		}
+		return fmt.Errorf("Failed to save expedition: %s", err.Error())
+	}
+
+	if err := models.TSaveIndex(t, e.Index); err != nil {
+		undo()
+		if rErr := t.Rewind(); rErr != nil {
+			e.logger.Error("[ExpeditionService] SpliceRewindRoute transaction rewind failed after save index.")
+		}
+		return fmt.Errorf("Failed to save index: %s", err.Error())
+	}
+
+	if err := t.Apply(); err != nil {
+		undo()
+		e.logger.Error("[ExpeditionService] SpliceRewindRoute transaction failed to apply.")
+		return fmt.Errorf("Failed to rewind expedition: %s", err.Error())
+	}
+	e.deleteBakedRoute(prevBakedRouteID)
+
+	e.bakedRoute = baked
+	e.currentJump = &expedition.JumpHistory[len(expedition.JumpHistory)-1]
+	e.CurrentJump.Publish(e.currentJump)
+
+	return nil
+}
+
+// spliceRoute returns a new baked route with the jumps of insert placed after
+// index at
+func spliceRoute(baked *models.Route, at int, insert *models.Route) *models.Route {
+	jumps := make([]models.RouteJump, 0, len(baked.Jumps)+len(insert.Jumps))
+	for i := 0; i <= at; i++ {
+		jumps = append(jumps, *baked.Jumps[i].Clone())
+	}
+	for i := range insert.Jumps {
+		jump := insert.Jumps[i].Clone()
+		if i == 0 {
+			// Respawning is not a jump
+			jump.Distance = 0
+		}
+		jumps = append(jumps, *jump)
+	}
+	for i := at + 1; i < len(baked.Jumps); i++ {
+		jumps = append(jumps, *baked.Jumps[i].Clone())
+	}
+
+	return &models.Route{
+		ID:              uuid.NewString(),
+		Name:            baked.Name,
+		Plotter:         baked.Plotter,
+		PlotterParams:   baked.PlotterParams,
+		PlotterMetadata: baked.PlotterMetadata,
+		Jumps:           jumps,
+		CreatedAt:       time.Now(),
+	}
+}
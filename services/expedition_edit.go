@@ -13,6 +13,9 @@ import (
 )
 
 func (e *ExpeditionService) AddRouteToExpedition(expeditionId string, route *models.Route) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
 	expedition, err := models.LoadExpedition(expeditionId)
 	if err != nil {
 		return fmt.Errorf("Failed to load expedition with id '%s': %s", expeditionId, err.Error())
@@ -91,6 +94,9 @@ func (e *ExpeditionService) AddRouteToExpedition(expeditionId string, route *mod
 }
 
 func (e *ExpeditionService) RenameExpedition(expeditionId, name string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
 	summary := slice.Find(
 		e.Index.Expeditions,
 		func(s models.ExpeditionSummary) bool { return s.ID == expeditionId },
@@ -146,7 +152,72 @@ func (e *ExpeditionService) RenameExpedition(expeditionId, name string) error {
 	return nil
 }
 
+// SetCountCarrierJumps opts the expedition in or out of counting fleet
+// carrier jumps as progress. Unlike other edits this is allowed while the
+// expedition is active.
+func (e *ExpeditionService) SetCountCarrierJumps(expeditionId string, count bool) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	summary := slice.Find(
+		e.Index.Expeditions,
+		func(s models.ExpeditionSummary) bool { return s.ID == expeditionId },
+	)
+
+	if summary == nil {
+		return fmt.Errorf("Failed to find expedition in index")
+	}
+
+	expedition, err := e.loadExpedition(expeditionId)
+	if err != nil {
+		return fmt.Errorf("Failed to load expedition")
+	}
+
+	if expedition.Status != models.StatusPlanned && expedition.Status != models.StatusActive {
+		return fmt.Errorf("Expedition is not editable")
+	}
+
+	prevCount := expedition.CountCarrierJumps
+	prevLastUpdated := expedition.LastUpdated
+	prevSummaryLastUpdated := summary.LastUpdated
+	undo := func() {
+		expedition.CountCarrierJumps = prevCount
+		expedition.LastUpdated = prevLastUpdated
+		summary.LastUpdated = prevSummaryLastUpdated
+	}
+
+	expedition.CountCarrierJumps = count
+	expedition.LastUpdated = time.Now()
+	summary.LastUpdated = expedition.LastUpdated
+
+	t := database.NewTransaction("ExpeditionSummary.SetCountCarrierJumps")
+
+	if err := models.TSaveExpedition(t, expedition); err != nil {
+		undo()
+		return fmt.Errorf("Failed to save expedition: %s", err.Error())
+	}
+
+	if err := models.TSaveIndex(t, e.Index); err != nil {
+		undo()
+		if rErr := t.Rewind(); rErr != nil {
+			e.logger.Error("[ExpeditionService] SetCountCarrierJumps transaction rewind failed.")
+		}
+		return fmt.Errorf("Failed to save index: %s", err.Error())
+	}
+
+	if err := t.Apply(); err != nil {
+		undo()
+		e.logger.Error("[ExpeditionService] SetCountCarrierJumps transaction failed to apply.")
+		return fmt.Errorf("Failed to update expedition: %s", err.Error())
+	}
+
+	return nil
+}
+
 func (e *ExpeditionService) RemoveRouteFromExpedition(expeditionId, routeId string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
 	expedition, err := models.LoadExpedition(expeditionId)
 	if err != nil {
 		return err
@@ -187,6 +258,9 @@ func (e *ExpeditionService) RemoveRouteFromExpedition(expeditionId, routeId stri
 }
 
 func (e *ExpeditionService) CreateLink(expeditionId string, from, to models.RoutePosition) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
 	expedition, err := models.LoadExpedition(expeditionId)
 	if err != nil {
 		return err
@@ -200,9 +274,12 @@ func (e *ExpeditionService) CreateLink(expeditionId string, from, to models.Rout
 		ID:   uuid.New().String(),
 		From: from,
 		To:   to,
+		// The first link from a system is the default, later ones are
+		// alternatives until made the default with SetLinkAlternative
+		Alternative: defaultLink(expedition.Links, from) != nil,
 	}
 
-	err = validateLink(expedition, link)
+	err = validateLink(expedition, link, models.LoadRoute)
 	if err != nil {
 		return err
 	}
@@ -214,6 +291,9 @@ func (e *ExpeditionService) CreateLink(expeditionId string, from, to models.Rout
 }
 
 func (e *ExpeditionService) DeleteLink(expeditionId, linkId string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
 	expedition, err := models.LoadExpedition(expeditionId)
 	if err != nil {
 		return err
@@ -234,7 +314,48 @@ func (e *ExpeditionService) DeleteLink(expeditionId, linkId string) error {
 	return models.SaveExpedition(expedition)
 }
 
-func validateLink(expedition *models.Expedition, link models.Link) error {
+// SetLinkAlternative makes a link an optional branch, or the default way on
+// from its system. Any other default link from the system becomes an
+// alternative.
+func (e *ExpeditionService) SetLinkAlternative(expeditionId, linkId string, alternative bool) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	expedition, err := models.LoadExpedition(expeditionId)
+	if err != nil {
+		return err
+	}
+
+	if !expedition.IsEditable() {
+		return fmt.Errorf("cannot change link: only planned expeditions can be edited")
+	}
+
+	linkIndex := slices.IndexFunc(expedition.Links, func(l models.Link) bool { return l.ID == linkId })
+	if linkIndex == -1 {
+		return fmt.Errorf("link not found in expedition")
+	}
+
+	link := &expedition.Links[linkIndex]
+	if !alternative {
+		if current := defaultLink(expedition.Links, link.From); current != nil {
+			current.Alternative = true
+		}
+	}
+	link.Alternative = alternative
+	expedition.LastUpdated = time.Now()
+
+	return models.SaveExpedition(expedition)
+}
+
+// defaultLink returns the default outgoing link from the system at pos, nil
+// without one
+func defaultLink(links []models.Link, pos models.RoutePosition) *models.Link {
+	return slice.Find(links, func(l models.Link) bool { return !l.Alternative && l.From.Equal(&pos) })
+}
+
+// validateLink checks that link connects the same system on two routes of the
+// expedition. Routes are looked up with loadRoute.
+func validateLink(expedition *models.Expedition, link models.Link, loadRoute func(id string) (*models.Route, error)) error {
 	if link.From.JumpIndex < 0 {
 		return errors.New("The 'from' jump index cannot be negative")
 	}
@@ -250,16 +371,19 @@ func validateLink(expedition *models.Expedition, link models.Link) error {
 	}
 
 	if slices.ContainsFunc(expedition.Links, func(l models.Link) bool {
-		return link.From.Equal(&l.From)
+		return link.From.Equal(&l.From) && link.To.Equal(&l.To)
 	}) {
-		return errors.New("There's already an outgoing link from the 'from' system")
+		return errors.New("These systems are already linked")
+	}
+	if !link.Alternative && defaultLink(expedition.Links, link.From) != nil {
+		return errors.New("There's already a default outgoing link from the 'from' system")
 	}
 
-	fromRoute, err := models.LoadRoute(link.From.RouteID)
+	fromRoute, err := loadRoute(link.From.RouteID)
 	if err != nil {
 		return fmt.Errorf("Failed to load 'from' route: %w", err)
 	}
-	toRoute, err := models.LoadRoute(link.To.RouteID)
+	toRoute, err := loadRoute(link.To.RouteID)
 	if err != nil {
 		return fmt.Errorf("Failed to load 'to' route: %w", err)
 	}
@@ -0,0 +1,162 @@
+package services
+
+import (
+	"ed-expedition/models"
+	"fmt"
+	"math"
+	"time"
+)
+
+const (
+	// Jumps the rolling interval is averaged over
+	etaWindow = 20
+	// A longer interval between two jumps is a break, not cadence
+	etaAFKGap = 10 * time.Minute
+	// Used until scooping has been timed on the expedition
+	etaDefaultRefuelTime = 60 * time.Second
+	// Time spent approaching a neutron star to supercharge
+	etaNeutronTime = 45 * time.Second
+	// Time spent synthesising an FSD injection
+	etaInjectionTime = 15 * time.Second
+)
+
+// ETAPrediction estimates the play time left to the end of the baked route.
+// Durations are in seconds, they are 0 until there are enough jumps to
+// measure the cadence.
+type ETAPrediction struct {
+	RemainingJumps int `json:"remainingJumps"`
+	// Jumps the interval was measured over
+	Samples      int     `json:"samples"`
+	JumpInterval float64 `json:"jumpInterval"`
+
+	RefuelStops int     `json:"refuelStops"`
+	RefuelTime  float64 `json:"refuelTime"`
+	BoostStops  int     `json:"boostStops"`
+	BoostTime   float64 `json:"boostTime"`
+
+	RemainingTime float64 `json:"remainingTime"`
+	// Average play session so far, 0 before the first session
+	SessionLength     float64 `json:"sessionLength"`
+	RemainingSessions int     `json:"remainingSessions"`
+}
+
+// PredictETA returns the prediction for the active expedition, nil without one
+func (e *ExpeditionService) PredictETA() *ETAPrediction {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.activeExpedition == nil || e.bakedRoute == nil {
+		return nil
+	}
+	return predictETA(e.activeExpedition, e.bakedRoute)
+}
+
+func (e *ExpeditionService) publishETA() {
+	if e.activeExpedition == nil || e.bakedRoute == nil {
+		return
+	}
+	prediction := predictETA(e.activeExpedition, e.bakedRoute)
+	e.logger.Trace(fmt.Sprintf("[ExpeditionService](ETA) %d jumps left, %.0fs per jump, %.0fs remaining", prediction.RemainingJumps, prediction.JumpInterval, prediction.RemainingTime))
+	e.ETA.Publish(prediction)
+}
+
+func predictETA(expedition *models.Expedition, route *models.Route) *ETAPrediction {
+	prediction := &ETAPrediction{}
+
+	interval, samples := jumpInterval(expedition.JumpHistory)
+	prediction.JumpInterval = interval.Seconds()
+	prediction.Samples = samples
+
+	refuelTime := averageScoopTime(expedition.JumpHistory)
+	prediction.addStretch(route, max(expedition.CurrentBakedIndex, 0), refuelTime)
+	// The laps left after this one, a route that loops forever is only
+	// predicted to the end of the lap
+	if expedition.BakedLoopBackIndex != nil && expedition.MaxLaps > 0 {
+		for range max(expedition.MaxLaps-expedition.CompletedLaps()-1, 0) {
+			prediction.addStretch(route, *expedition.BakedLoopBackIndex, refuelTime)
+		}
+	}
+
+	if samples > 0 {
+		prediction.RemainingTime = float64(prediction.RemainingJumps)*prediction.JumpInterval +
+			prediction.RefuelTime + prediction.BoostTime
+	}
+
+	stats := computeExpeditionStats(expedition, route)
+	if len(stats.Sessions) > 0 {
+		prediction.SessionLength = stats.PlayTime / float64(len(stats.Sessions))
+	}
+	if prediction.SessionLength > 0 {
+		prediction.RemainingSessions = int(math.Ceil(prediction.RemainingTime / prediction.SessionLength))
+	}
+
+	return prediction
+}
+
+// addStretch adds the jumps and planned stops from the system at index to the
+// end of the route
+func (prediction *ETAPrediction) addStretch(route *models.Route, index int, refuelTime time.Duration) {
+	prediction.RemainingJumps += max(len(route.Jumps)-1-index, 0)
+	for i := index; i < len(route.Jumps)-1; i++ {
+		// Both are planned on the system the jump leaves from
+		jump := route.Jumps[i]
+		if jump.MustRefuel {
+			prediction.RefuelStops++
+			prediction.RefuelTime += refuelTime.Seconds()
+		}
+		if jump.FSDBoost == nil {
+			continue
+		}
+		switch *jump.FSDBoost {
+		case models.FSDBoostNone:
+		case models.FSDBoostNeutron:
+			prediction.BoostStops++
+			prediction.BoostTime += etaNeutronTime.Seconds()
+		default:
+			prediction.BoostStops++
+			prediction.BoostTime += etaInjectionTime.Seconds()
+		}
+	}
+}
+
+// jumpInterval averages the time between the most recent jumps. Breaks are
+// left out, and so are jumps with a scoop or boost before them since those
+// stops are added separately.
+func jumpInterval(history []models.JumpHistoryEntry) (time.Duration, int) {
+	var total time.Duration
+	samples := 0
+	for i := len(history) - 1; i > 0 && samples < etaWindow; i-- {
+		prev, jump := history[i-1], history[i]
+		if jump.Synthetic || jump.Death != nil || jump.Resumed || jump.OffExpedition || jump.Carrier || jump.Erroneous {
+			continue
+		}
+		if prev.Erroneous || prev.FuelScoop != nil || jump.FSDBoost != nil {
+			continue
+		}
+		interval := jump.Timestamp.Sub(prev.Timestamp)
+		if interval <= 0 || interval > etaAFKGap {
+			continue
+		}
+		total += interval
+		samples++
+	}
+	if samples == 0 {
+		return 0, 0
+	}
+	return total / time.Duration(samples), samples
+}
+
+func averageScoopTime(history []models.JumpHistoryEntry) time.Duration {
+	var total float64
+	count := 0
+	for _, jump := range history {
+		if jump.FuelScoop != nil && jump.FuelScoop.Duration > 0 {
+			total += jump.FuelScoop.Duration
+			count++
+		}
+	}
+	if count == 0 {
+		return etaDefaultRefuelTime
+	}
+	return time.Duration(total / float64(count) * float64(time.Second))
+}
@@ -0,0 +1,161 @@
+package services
+
+import (
+	"ed-expedition/journal"
+	"ed-expedition/models"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Honking a system emits a burst of Scan events, so saves are debounced
+// instead of writing the expedition once per body.
+const explorationSaveDelay = 2 * time.Second
+
+func (e *ExpeditionService) handleScan(event *journal.ScanEvent) {
+	exploration := e.explorationFor(event.SystemAddress)
+	if exploration == nil {
+		return
+	}
+
+	bodyType := event.StarType
+	if bodyType == "" {
+		bodyType = event.PlanetClass
+	}
+	body := models.ScannedBody{
+		BodyID:         event.BodyID,
+		BodyName:       event.BodyName,
+		Type:           bodyType,
+		TerraformState: event.TerraformState,
+		Landable:       event.Landable,
+		WasDiscovered:  event.WasDiscovered,
+		WasMapped:      event.WasMapped,
+	}
+
+	// A body is usually scanned more than once (auto scan on arrival, then a
+	// detailed scan), keep the latest.
+	i := slices.IndexFunc(exploration.ScannedBodies, func(b models.ScannedBody) bool { return b.BodyID == event.BodyID })
+	if i > -1 {
+		exploration.ScannedBodies[i] = body
+	} else {
+		exploration.ScannedBodies = append(exploration.ScannedBodies, body)
+	}
+
+	e.explorationUpdated(event.SystemAddress)
+}
+
+func (e *ExpeditionService) handleFSSDiscoveryScan(event *journal.FSSDiscoveryScanEvent) {
+	exploration := e.explorationFor(event.SystemAddress)
+	if exploration == nil {
+		return
+	}
+
+	exploration.Honked = true
+	exploration.BodyCount = event.BodyCount
+	exploration.NonBodyCount = event.NonBodyCount
+
+	e.explorationUpdated(event.SystemAddress)
+}
+
+func (e *ExpeditionService) handleFSSAllBodiesFound(event *journal.FSSAllBodiesFoundEvent) {
+	exploration := e.explorationFor(event.SystemAddress)
+	if exploration == nil {
+		return
+	}
+
+	exploration.AllBodiesFound = true
+	if exploration.BodyCount < event.Count {
+		exploration.BodyCount = event.Count
+	}
+
+	e.explorationUpdated(event.SystemAddress)
+}
+
+func (e *ExpeditionService) handleSAASignalsFound(event *journal.SAASignalsFoundEvent) {
+	exploration := e.explorationFor(event.SystemAddress)
+	if exploration == nil {
+		return
+	}
+
+	signals := make([]models.BodySignal, len(event.Signals))
+	for i, signal := range event.Signals {
+		signalType := signal.TypeLocalised
+		if signalType == "" {
+			signalType = signal.Type
+		}
+		signals[i] = models.BodySignal{Type: signalType, Count: signal.Count}
+	}
+	body := models.MappedBody{
+		BodyID:   event.BodyID,
+		BodyName: event.BodyName,
+		Signals:  signals,
+	}
+
+	i := slices.IndexFunc(exploration.MappedBodies, func(b models.MappedBody) bool { return b.BodyID == event.BodyID })
+	if i > -1 {
+		exploration.MappedBodies[i] = body
+	} else {
+		exploration.MappedBodies = append(exploration.MappedBodies, body)
+	}
+
+	e.explorationUpdated(event.SystemAddress)
+}
+
+// explorationFor returns the exploration record of the most recent jump into
+// the given system, creating it if needed. Returns nil if there is no active
+// expedition or we have no jump into that system.
+func (e *ExpeditionService) explorationFor(systemAddress int64) *models.SystemExploration {
+	if e.isOtherCommander(e.currentCommander()) {
+		return nil
+	}
+
+	jump := e.findJumpForSystem(systemAddress)
+	if jump == nil {
+		e.logger.Trace(fmt.Sprintf("[ExpeditionService](Exploration) no jump found for system %d, skipping", systemAddress))
+		return nil
+	}
+
+	if jump.Exploration == nil {
+		jump.Exploration = &models.SystemExploration{
+			ScannedBodies: []models.ScannedBody{},
+			MappedBodies:  []models.MappedBody{},
+		}
+	}
+	return jump.Exploration
+}
+
+func (e *ExpeditionService) findJumpForSystem(systemAddress int64) *models.JumpHistoryEntry {
+	if e.activeExpedition == nil {
+		return nil
+	}
+
+	history := e.activeExpedition.JumpHistory
+	for i := len(history) - 1; i >= 0; i-- {
+		if history[i].SystemID == systemAddress {
+			return &history[i]
+		}
+	}
+	return nil
+}
+
+func (e *ExpeditionService) explorationUpdated(systemAddress int64) {
+	if e.currentJump != nil && e.currentJump.SystemID == systemAddress {
+		e.CurrentJump.Publish(e.currentJump)
+	}
+
+	if e.explorationSaveTimer != nil {
+		e.explorationSaveTimer.Stop()
+	}
+	e.explorationSaveTimer = time.AfterFunc(explorationSaveDelay, func() {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+
+		if e.activeExpedition == nil {
+			return
+		}
+
+		if err := models.SaveExpedition(e.activeExpedition); err != nil {
+			e.logger.Error(fmt.Sprintf("Failed to save expedition after exploration update: %s", err.Error()))
+		}
+	})
+}
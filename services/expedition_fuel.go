@@ -2,6 +2,7 @@ package services
 
 import (
 	"ed-expedition/journal"
+	"ed-expedition/lib/slice"
 	"ed-expedition/models"
 	"fmt"
 	"strconv"
@@ -22,27 +23,117 @@ type FuelAlert struct {
 	Message string         `json:"message"`
 }
 
-func (e *ExpeditionService) handleRefueling(scooping bool) {
-	if e.activeExpedition != nil && e.previouslyScooping && !scooping {
-		go func() {
-			time.Sleep(time.Second)
+// Scooping stops with both a status change and a FuelScoop event, saves wait
+// for both.
+const scoopSaveDelay = time.Second
 
-			if e.activeExpedition == nil {
-				return
-			}
+func (e *ExpeditionService) handleRefueling(scooping bool) {
+	if e.isOtherCommander(e.currentCommander()) {
+		return
+	}
 
-			err := models.SaveExpedition(e.activeExpedition)
-			if err != nil {
-				e.logger.Error(fmt.Sprintf("Failed to save expedition after refueling: %s", err.Error()))
-			}
-		}()
+	e.scoopMu.Lock()
+	if scooping && !e.previouslyScooping {
+		e.scoopingSince = time.Now()
+	}
+	stopped := e.activeExpedition != nil && e.currentJump != nil && e.previouslyScooping && !scooping
+	if stopped {
+		record := scoopRecord(e.currentJump)
+		record.Duration += time.Since(e.scoopingSince).Seconds()
 	}
 	e.previouslyScooping = scooping
+	e.scoopMu.Unlock()
+
+	if stopped {
+		e.scoopUpdated()
+	}
+}
+
+func (e *ExpeditionService) handleFuelScoop(event *journal.FuelScoopEvent) {
+	if e.activeExpedition == nil || e.currentJump == nil || e.isOtherCommander(event.CommanderFID) {
+		return
+	}
+	if event.Timestamp.Before(e.currentJump.Timestamp) {
+		return
+	}
+
+	e.scoopMu.Lock()
+	record := scoopRecord(e.currentJump)
+	if record.Scooped == 0 {
+		record.StartFuel = event.Total - event.Scooped
+	}
+	record.Scooped += event.Scooped
+	record.EndFuel = event.Total
+	e.scoopMu.Unlock()
+
+	e.logger.Trace(fmt.Sprintf("[ExpeditionService](Fuel) scooped %.2ft in %s", event.Scooped, e.currentJump.SystemName))
+	e.scoopUpdated()
+}
+
+func scoopRecord(jump *models.JumpHistoryEntry) *models.FuelScoopRecord {
+	if jump.FuelScoop == nil {
+		jump.FuelScoop = &models.FuelScoopRecord{}
+	}
+	return jump.FuelScoop
+}
+
+func (e *ExpeditionService) scoopUpdated() {
+	if e.currentJump != nil {
+		e.CurrentJump.Publish(e.currentJump)
+	}
+
+	e.scoopMu.Lock()
+	defer e.scoopMu.Unlock()
+	if e.scoopSaveTimer != nil {
+		e.scoopSaveTimer.Stop()
+	}
+	e.scoopSaveTimer = time.AfterFunc(scoopSaveDelay, func() {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+
+		expedition := e.activeExpedition
+		if expedition == nil {
+			return
+		}
+
+		if err := models.SaveExpedition(expedition); err != nil {
+			e.logger.Error(fmt.Sprintf("Failed to save expedition after refueling: %s", err.Error()))
+			return
+		}
+
+		summary := slice.Find(
+			e.Index.Expeditions,
+			func(s models.ExpeditionSummary) bool { return s.ID == expedition.ID },
+		)
+		if summary == nil {
+			return
+		}
+		summary.FuelScooped, summary.ScoopDuration = scoopTotals(expedition.JumpHistory)
+		if err := models.SaveIndex(e.Index); err != nil {
+			e.logger.Error(fmt.Sprintf("Failed to save index after refueling: %s", err.Error()))
+		}
+	})
+}
+
+// scoopTotals returns the fuel scooped and the seconds spent scooping over
+// the whole history
+func scoopTotals(history []models.JumpHistoryEntry) (scooped, duration float64) {
+	for _, jump := range history {
+		if jump.FuelScoop != nil {
+			scooped += jump.FuelScoop.Scooped
+			duration += jump.FuelScoop.Duration
+		}
+	}
+	return scooped, duration
 }
 
 func (e *ExpeditionService) handleFuelChange(fuel *journal.FuelStatus) {
 	e.logger.Trace(fmt.Sprintf("[ExpeditionService](Fuel) handleFuelChange: fuel=%.2f, jumpInProgress=%v", fuel.FuelMain, e.isJumpInProgress()))
 
+	if e.isOtherCommander(e.currentCommander()) {
+		return
+	}
+
 	if e.currentJump != nil {
 		if e.isJumpInProgress() {
 			e.logger.Trace(fmt.Sprintf("[ExpeditionService](Fuel) handleFuelChange: jump in progress, skipping update to current jump '%s'", e.currentJump.SystemName))
@@ -57,12 +148,18 @@ func (e *ExpeditionService) handleFuelChange(fuel *journal.FuelStatus) {
 }
 
 func (e *ExpeditionService) handleJumpFuel(jump *journal.FSDJumpEvent) {
+	if e.isOtherCommander(jump.CommanderFID) || !jump.InOwnShip() {
+		return
+	}
 	time.AfterFunc(time.Second/2, func() {
 		e.handleFuelNotification(&journal.FuelStatus{FuelMain: jump.FuelLevel})
 	})
 }
 
 func (e *ExpeditionService) handleFuelNotification(fuel *journal.FuelStatus) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
 	if e.isJumpInProgress() {
 		return
 	}
@@ -66,6 +66,15 @@ func (e *ExpeditionService) stopChargingTimeoutLocked() {
 	}
 }
 
+// SetPassengerJumpPolicy sets what to do with jumps made in a taxi or as
+// multicrew.
+func (e *ExpeditionService) SetPassengerJumpPolicy(policy models.PassengerJumpPolicy) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.passengerJumps = policy
+}
+
 func (e *ExpeditionService) handleStartJump(event *journal.StartJumpEvent) {
 	e.jumpStateMu.Lock()
 	defer e.jumpStateMu.Unlock()
@@ -106,29 +115,89 @@ func (e *ExpeditionService) handleJump(event *journal.FSDJumpEvent) {
 	e.logger.Trace(fmt.Sprintf("[ExpeditionService](Jump) handleJump: system=%s, state=%d", event.StarSystem, e.jumpState))
 	e.setJumpState(jumpStateNormal)
 
+	// The Commander event may not have been handled yet
+	if err := e.switchCommander(event.CommanderFID); err != nil {
+		e.logger.Error(fmt.Sprintf("[ExpeditionService](Jump) Failed to switch to the expedition of %s: %s", event.CommanderFID, err.Error()))
+	}
 	if e.activeExpedition == nil {
 		return
 	}
+	if e.isOtherCommander(event.CommanderFID) {
+		e.logger.Trace(fmt.Sprintf("[ExpeditionService](Jump) handleJump: jump by other commander %s, ignoring", event.CommanderFID))
+		return
+	}
 	jumpHistory := e.activeExpedition.JumpHistory
 	if len(jumpHistory) > 0 && !jumpHistory[len(jumpHistory)-1].Timestamp.Before(event.Timestamp) {
 		return
 	}
+	if !event.InOwnShip() {
+		e.handlePassengerJump(event)
+		return
+	}
 	e.logger.Info(fmt.Sprintf("[ExpeditionService](Jump) Handle jump to %s", event.StarSystem))
+	if !event.Carrier {
+		e.consumeBoost()
+	}
 
 	if e.activeExpedition.CurrentBakedIndex >= len(e.bakedRoute.Jumps)-1 {
 		e.logger.Warning("Received jump but no more expected jumps in route. This should only happen if your have only one jump in your expedition.")
 		return
 	}
 
-	expectedSystem := e.bakedRoute.Jumps[e.activeExpedition.CurrentBakedIndex+1]
+	e.followBranch(event)
+	historicalJump, bakedIndex := matchJumpToRoute(e.bakedRoute, e.activeExpedition.CurrentBakedIndex, event)
+	e.activeExpedition.CurrentBakedIndex = bakedIndex
+	historicalJump.Health = e.healthSnapshot()
+
+	e.activeExpedition.JumpHistory = append(e.activeExpedition.JumpHistory, historicalJump)
+	e.activeExpedition.LastUpdated = time.Now()
+
+	if e.activeExpedition.CurrentBakedIndex >= len(e.bakedRoute.Jumps)-1 {
+		if e.activeExpedition.BakedLoopBackIndex != nil {
+			e.activeExpedition.Laps = computeLaps(e.activeExpedition, e.bakedRoute)
+			e.logger.Info(fmt.Sprintf("[ExpeditionService](Jump) Completed lap %d", e.activeExpedition.CompletedLaps()))
+		}
+		if !atRouteEnd(e.activeExpedition, e.bakedRoute) {
+			e.activeExpedition.CurrentBakedIndex = *e.activeExpedition.BakedLoopBackIndex
+		} else {
+			if err := e.completeActiveExpedition(); err != nil {
+				panic("Failed to complete expedition")
+			}
+			return
+		}
+	}
+
+	e.currentJump = &e.activeExpedition.JumpHistory[len(e.activeExpedition.JumpHistory)-1]
+	e.trackRejoin(e.currentJump, event.StarPos)
+	e.saveJump(&historicalJump)
+	e.checkNextBoost()
+	e.checkFSDHealth()
+	e.publishETA()
+}
+
+// handleCarrierJump counts a jump of the fleet carrier the commander is docked
+// on as their own when the expedition opted in
+func (e *ExpeditionService) handleCarrierJump(event *journal.CarrierJumpEvent) {
+	if e.activeExpedition == nil || !e.activeExpedition.CountCarrierJumps {
+		e.logger.Trace(fmt.Sprintf("[ExpeditionService](Jump) handleCarrierJump: carrier jump to %s not counted", event.StarSystem))
+		return
+	}
+	e.handleJump(event.FSDJump())
+}
+
+// matchJumpToRoute builds the history entry for a jump and works out where on
+// the baked route it landed. Returns the entry and the new current baked index,
+// which is unchanged if the jump went off route.
+func matchJumpToRoute(route *models.Route, currentIndex int, event *journal.FSDJumpEvent) (models.JumpHistoryEntry, int) {
+	expectedSystem := route.Jumps[currentIndex+1]
 	isExpected := event.SystemAddress == expectedSystem.SystemID
 
 	// This is required because at the time of starting the expedition its not
 	// necessarily guarantieed that we know the players current position.
 	// If that is the case the expedition would have started with index -1 where
 	// it maybe should have been 0
-	if !isExpected && e.activeExpedition.CurrentBakedIndex == -1 && len(e.bakedRoute.Jumps) > 1 && e.bakedRoute.Jumps[1].SystemID == event.SystemAddress {
-		e.activeExpedition.CurrentBakedIndex++
+	if !isExpected && currentIndex == -1 && len(route.Jumps) > 1 && route.Jumps[1].SystemID == event.SystemAddress {
+		currentIndex++
 		isExpected = true
 	}
 
@@ -140,42 +209,68 @@ func (e *ExpeditionService) handleJump(event *journal.FSDJumpEvent) {
 		Distance:  event.JumpDist,
 		FuelUsed:  event.FuelUsed,
 		FuelLevel: event.FuelLevel,
+		FSDBoost:  event.Boost,
+		Carrier:   event.Carrier,
 
 		Expected:  isExpected,
 		Synthetic: false,
 	}
 
 	if isExpected {
-		e.activeExpedition.CurrentBakedIndex++
+		currentIndex++
 
-		cpy := e.activeExpedition.CurrentBakedIndex
+		cpy := currentIndex
 		historicalJump.BakedIndex = &cpy
 	} else {
-		for i := e.activeExpedition.CurrentBakedIndex + 2; i < len(e.bakedRoute.Jumps); i++ {
-			if e.bakedRoute.Jumps[i].SystemID == event.SystemAddress {
+		for i := currentIndex + 2; i < len(route.Jumps); i++ {
+			if route.Jumps[i].SystemID == event.SystemAddress {
 				historicalJump.BakedIndex = &i
-				e.activeExpedition.CurrentBakedIndex = i
+				currentIndex = i
 				break
 			}
 		}
 	}
 
-	e.activeExpedition.JumpHistory = append(e.activeExpedition.JumpHistory, historicalJump)
-	e.activeExpedition.LastUpdated = time.Now()
+	// Carrier jumps have no distance in the journal, take it from the route
+	if event.Carrier && historicalJump.BakedIndex != nil {
+		historicalJump.Distance = route.Jumps[*historicalJump.BakedIndex].Distance
+	}
 
-	if e.activeExpedition.CurrentBakedIndex >= len(e.bakedRoute.Jumps)-1 {
-		if e.activeExpedition.BakedLoopBackIndex != nil {
-			e.activeExpedition.CurrentBakedIndex = *e.activeExpedition.BakedLoopBackIndex
-		} else {
-			if err := e.completeActiveExpedition(); err != nil {
-				panic("Failed to complete expedition")
-			}
-			return
-		}
+	return historicalJump, currentIndex
+}
+
+// handlePassengerJump deals with jumps made in a taxi or as multicrew according
+// to the configured policy. They never move us along the route.
+func (e *ExpeditionService) handlePassengerJump(event *journal.FSDJumpEvent) {
+	if e.passengerJumps != models.PassengerJumpRecord {
+		e.logger.Trace(fmt.Sprintf("[ExpeditionService](Jump) handleJump: passenger jump to %s (taxi=%v, multicrew=%v), ignoring", event.StarSystem, event.Taxi, event.Multicrew))
+		return
 	}
+	e.logger.Info(fmt.Sprintf("[ExpeditionService](Jump) Recording passenger jump to %s as off-expedition", event.StarSystem))
 
+	historicalJump := passengerJumpEntry(event)
+
+	e.activeExpedition.JumpHistory = append(e.activeExpedition.JumpHistory, historicalJump)
+	e.activeExpedition.LastUpdated = time.Now()
 	e.currentJump = &e.activeExpedition.JumpHistory[len(e.activeExpedition.JumpHistory)-1]
+	e.saveJump(&historicalJump)
+}
+
+func passengerJumpEntry(event *journal.FSDJumpEvent) models.JumpHistoryEntry {
+	return models.JumpHistoryEntry{
+		Timestamp:  event.Timestamp,
+		SystemName: event.StarSystem,
+		SystemID:   event.SystemAddress,
+
+		Distance: event.JumpDist,
+
+		Expected:      false,
+		Synthetic:     false,
+		OffExpedition: true,
+	}
+}
 
+func (e *ExpeditionService) saveJump(historicalJump *models.JumpHistoryEntry) {
 	err := models.SaveExpedition(e.activeExpedition)
 	if err != nil {
 		panic("Failed to save expedition after jump")
@@ -193,5 +288,5 @@ func (e *ExpeditionService) handleJump(event *journal.FSDJumpEvent) {
 		}
 	}
 
-	e.JumpHistory.Publish(&historicalJump)
+	e.JumpHistory.Publish(historicalJump)
 }
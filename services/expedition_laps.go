@@ -0,0 +1,142 @@
+package services
+
+import (
+	"ed-expedition/database"
+	"ed-expedition/lib/slice"
+	"ed-expedition/models"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// SetMaxLaps sets after how many laps a looping expedition is completed, 0
+// loops forever. Like SetCountCarrierJumps this is allowed while the
+// expedition is active, it takes effect at the end of the lap in progress.
+func (e *ExpeditionService) SetMaxLaps(expeditionId string, laps int) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if laps < 0 {
+		return errors.New("The number of laps cannot be negative")
+	}
+
+	summary := slice.Find(
+		e.Index.Expeditions,
+		func(s models.ExpeditionSummary) bool { return s.ID == expeditionId },
+	)
+
+	if summary == nil {
+		return fmt.Errorf("Failed to find expedition in index")
+	}
+
+	expedition, err := e.loadExpedition(expeditionId)
+	if err != nil {
+		return fmt.Errorf("Failed to load expedition")
+	}
+
+	if expedition.Status != models.StatusPlanned && expedition.Status != models.StatusActive {
+		return fmt.Errorf("Expedition is not editable")
+	}
+
+	prevMaxLaps := expedition.MaxLaps
+	prevLastUpdated := expedition.LastUpdated
+	prevSummaryLastUpdated := summary.LastUpdated
+	undo := func() {
+		expedition.MaxLaps = prevMaxLaps
+		expedition.LastUpdated = prevLastUpdated
+		summary.LastUpdated = prevSummaryLastUpdated
+	}
+
+	expedition.MaxLaps = laps
+	expedition.LastUpdated = time.Now()
+	summary.LastUpdated = expedition.LastUpdated
+
+	t := database.NewTransaction("ExpeditionSummary.SetMaxLaps")
+
+	if err := models.TSaveExpedition(t, expedition); err != nil {
+		undo()
+		return fmt.Errorf("Failed to save expedition: %s", err.Error())
+	}
+
+	if err := models.TSaveIndex(t, e.Index); err != nil {
+		undo()
+		if rErr := t.Rewind(); rErr != nil {
+			e.logger.Error("[ExpeditionService] SetMaxLaps transaction rewind failed.")
+		}
+		return fmt.Errorf("Failed to save index: %s", err.Error())
+	}
+
+	if err := t.Apply(); err != nil {
+		undo()
+		e.logger.Error("[ExpeditionService] SetMaxLaps transaction failed to apply.")
+		return fmt.Errorf("Failed to update expedition: %s", err.Error())
+	}
+
+	return nil
+}
+
+// atRouteEnd reports whether the expedition is done with the baked route,
+// either at the end of a route that does not loop or out of laps
+func atRouteEnd(expedition *models.Expedition, route *models.Route) bool {
+	if expedition.CurrentBakedIndex < len(route.Jumps)-1 {
+		return false
+	}
+	return expedition.BakedLoopBackIndex == nil || expedition.LapsDone()
+}
+
+// wrapLoop moves an expedition on the last system of a looping baked route
+// back to where the loop starts over, like handleJump does after the jump that
+// ends a lap. Anything that sets the current baked index by hand has to do the
+// same, otherwise no jump is expected after it. Reports whether it wrapped.
+func wrapLoop(expedition *models.Expedition, route *models.Route) bool {
+	if expedition.BakedLoopBackIndex == nil || expedition.CurrentBakedIndex < len(route.Jumps)-1 {
+		return false
+	}
+	expedition.Laps = computeLaps(expedition, route)
+	if atRouteEnd(expedition, route) {
+		return false
+	}
+	expedition.CurrentBakedIndex = *expedition.BakedLoopBackIndex
+	return true
+}
+
+// computeLaps works out the laps of a looping expedition from the jump
+// history. A lap ends with the jump to the last system on the baked route,
+// which is where the loop starts over.
+func computeLaps(expedition *models.Expedition, route *models.Route) []models.Lap {
+	if expedition.BakedLoopBackIndex == nil || expedition.StartedOn.IsZero() {
+		return nil
+	}
+
+	last := len(route.Jumps) - 1
+	laps := []models.Lap{{Number: 1, Start: expedition.StartedOn}}
+	for _, entry := range expedition.JumpHistory {
+		if entry.Erroneous || entry.BakedIndex == nil || *entry.BakedIndex != last {
+			continue
+		}
+		lap := &laps[len(laps)-1]
+		lap.End = entry.Timestamp
+		lap.Stats = lapStats(expedition, route, *lap)
+		laps = append(laps, models.Lap{Number: lap.Number + 1, Start: entry.Timestamp})
+	}
+
+	// There is no next lap when that was the last one
+	if expedition.MaxLaps > 0 && len(laps)-1 >= expedition.MaxLaps {
+		laps = laps[:len(laps)-1]
+	}
+
+	return laps
+}
+
+func lapStats(expedition *models.Expedition, route *models.Route, lap models.Lap) *models.ExpeditionStats {
+	lapExpedition := *expedition
+	lapExpedition.StartedOn = lap.Start
+	lapExpedition.Status = models.StatusCompleted
+	lapExpedition.JumpHistory = make([]models.JumpHistoryEntry, 0)
+	for _, entry := range expedition.JumpHistory {
+		if entry.Timestamp.After(lap.Start) && !entry.Timestamp.After(lap.End) {
+			lapExpedition.JumpHistory = append(lapExpedition.JumpHistory, entry)
+		}
+	}
+	return computeExpeditionStats(&lapExpedition, route)
+}
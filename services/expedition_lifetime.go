@@ -29,10 +29,12 @@ func (e *ExpeditionService) completeActiveExpedition() error {
 	}
 
 	prevLastUpdated := expedition.LastUpdated
+	prevStats := expedition.Stats
 	undo := func() {
 		expedition.EndedOn = time.Time{}
 		expedition.LastUpdated = prevLastUpdated
 		expedition.Status = models.StatusActive
+		expedition.Stats = prevStats
 
 		expeditionSummary.Status = models.StatusActive
 		expeditionSummary.LastUpdated = prevLastUpdated
@@ -42,6 +44,7 @@ func (e *ExpeditionService) completeActiveExpedition() error {
 	expedition.EndedOn = time.Now()
 	expedition.LastUpdated = time.Now()
 	expedition.Status = models.StatusCompleted
+	expedition.Stats = computeExpeditionStats(expedition, e.bakedRoute)
 
 	expeditionSummary.Status = models.StatusCompleted
 	expeditionSummary.LastUpdated = time.Now()
@@ -80,6 +83,9 @@ func (e *ExpeditionService) completeActiveExpedition() error {
 }
 
 func (e *ExpeditionService) CreateExpedition() (string, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
 	now := time.Now()
 	id := uuid.New().String()
 
@@ -135,6 +141,9 @@ func (e *ExpeditionService) CreateExpedition() (string, error) {
 // references are copied (not the route files). The baked route and jump history
 // are intentionally dropped: they are (re)generated when the clone is started.
 func (e *ExpeditionService) CloneExpedition(expeditionId string) (string, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
 	source, err := models.LoadExpedition(expeditionId)
 	if err != nil {
 		return "", fmt.Errorf("Failed to load expedition to clone: %s", err.Error())
@@ -146,9 +155,10 @@ func (e *ExpeditionService) CloneExpedition(expeditionId string) (string, error)
 	links := make([]models.Link, len(source.Links))
 	for i, link := range source.Links {
 		links[i] = models.Link{
-			ID:   uuid.New().String(),
-			From: link.From,
-			To:   link.To,
+			ID:          uuid.New().String(),
+			From:        link.From,
+			To:          link.To,
+			Alternative: link.Alternative,
 		}
 	}
 
@@ -167,6 +177,9 @@ func (e *ExpeditionService) CloneExpedition(expeditionId string) (string, error)
 		Routes:      slices.Clone(source.Routes),
 		Links:       links,
 		JumpHistory: []models.JumpHistoryEntry{},
+
+		CountCarrierJumps: source.CountCarrierJumps,
+		MaxLaps:           source.MaxLaps,
 	}
 
 	summary := models.ExpeditionSummary{
@@ -203,6 +216,9 @@ func (e *ExpeditionService) CloneExpedition(expeditionId string) (string, error)
 }
 
 func (e *ExpeditionService) DeleteExpedition(expeditionId string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
 	summaryIndex := slices.IndexFunc(
 		e.Index.Expeditions,
 		func(s models.ExpeditionSummary) bool { return s.ID == expeditionId },
@@ -215,7 +231,7 @@ func (e *ExpeditionService) DeleteExpedition(expeditionId string) error {
 	// Any expedition can be deleted except the active one, whose in-memory state
 	// and index pointer would be left dangling. History is a soft preference, not
 	// a hard constraint, so completed/ended records are fair game to discard.
-	if e.Index.ActiveExpeditionID != nil && *e.Index.ActiveExpeditionID == expeditionId {
+	if e.Index.IsActive(expeditionId) {
 		return fmt.Errorf("cannot delete expedition: end the active expedition first")
 	}
 
@@ -239,6 +255,13 @@ func (e *ExpeditionService) DeleteExpedition(expeditionId string) error {
 }
 
 func (e *ExpeditionService) EndActiveExpedition(t *database.Transaction) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.endActiveExpedition(t)
+}
+
+func (e *ExpeditionService) endActiveExpedition(t *database.Transaction) error {
 	if e.activeExpedition == nil {
 		// TODO: This should maybe be an error?
 		return nil
@@ -255,10 +278,12 @@ func (e *ExpeditionService) EndActiveExpedition(t *database.Transaction) error {
 	prevActiveExpeditionLastUpdated := e.activeExpedition.LastUpdated
 	prevActiveExpeditionStatus := e.activeExpedition.Status
 	prevActiveExpeditionId := *e.Index.ActiveExpeditionID
+	prevActiveExpeditionStats := e.activeExpedition.Stats
 	undo := func() {
 		e.activeExpedition.EndedOn = time.Time{}
 		e.activeExpedition.LastUpdated = prevActiveExpeditionLastUpdated
 		e.activeExpedition.Status = prevActiveExpeditionStatus
+		e.activeExpedition.Stats = prevActiveExpeditionStats
 
 		expeditionSummary.Status = prevActiveExpeditionStatus
 		expeditionSummary.LastUpdated = prevActiveExpeditionLastUpdated
@@ -268,6 +293,7 @@ func (e *ExpeditionService) EndActiveExpedition(t *database.Transaction) error {
 	e.activeExpedition.EndedOn = time.Now()
 	e.activeExpedition.LastUpdated = time.Now()
 	e.activeExpedition.Status = models.StatusEnded
+	e.activeExpedition.Stats = computeExpeditionStats(e.activeExpedition, e.bakedRoute)
 
 	expeditionSummary.Status = models.StatusEnded
 	expeditionSummary.LastUpdated = time.Now()
@@ -312,7 +338,10 @@ func (e *ExpeditionService) EndActiveExpedition(t *database.Transaction) error {
 	return nil
 }
 
-func (e *ExpeditionService) StartExpedition(expeditionId string, currentSystemId *int64) error {
+func (e *ExpeditionService) StartExpedition(expeditionId string, currentSystemId *int64, commanderFID string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
 	expeditionSummary := slice.Find(
 		e.Index.Expeditions,
 		func(exp models.ExpeditionSummary) bool { return exp.ID == expeditionId },
@@ -330,12 +359,19 @@ func (e *ExpeditionService) StartExpedition(expeditionId string, currentSystemId
 	if err != nil {
 		return fmt.Errorf("Cannot start expedition: %s", err.Error())
 	}
+	if _, ok := e.Index.ParkedExpeditions[commanderFID]; ok {
+		return errors.New("Cannot start expedition: the commander has an active expedition, log in to end it first")
+	}
 
 	route, loopBackIndex, err := bakeExpeditionRoute(expedition)
 	if err != nil {
 		return err
 	}
 
+	if err := e.preflight(route); err != nil {
+		return err
+	}
+
 	currentSystemIsStart := currentSystemId != nil && route.Jumps[0].SystemID == *currentSystemId
 	expedition.CurrentBakedIndex = -1
 	if currentSystemIsStart {
@@ -361,12 +397,14 @@ func (e *ExpeditionService) StartExpedition(expeditionId string, currentSystemId
 	}
 
 	expedition.BakedRouteID = &route.ID
+	expedition.CommanderFID = commanderFID
 	if loopBackIndex > -1 {
 		expedition.BakedLoopBackIndex = &loopBackIndex
 	}
 	expedition.StartedOn = time.Now()
 	expedition.LastUpdated = time.Now()
 	expedition.Status = models.StatusActive
+	expedition.Laps = computeLaps(expedition, route)
 
 	prevActiveExpeditionId := e.Index.ActiveExpeditionID
 	prevLastUpdated := expeditionSummary.LastUpdated
@@ -388,7 +426,7 @@ func (e *ExpeditionService) StartExpedition(expeditionId string, currentSystemId
 		return fmt.Errorf("Failed to save route: %s", err.Error())
 	}
 
-	err = e.EndActiveExpedition(t)
+	err = e.endActiveExpedition(t)
 	if err != nil {
 		undo()
 		if rErr := t.Rewind(); rErr != nil {
@@ -431,9 +469,19 @@ func (e *ExpeditionService) StartExpedition(expeditionId string, currentSystemId
 }
 
 func bakeExpeditionRoute(expedition *models.Expedition) (*models.Route, int, error) {
+	routeById, err := loadRouteMap(expedition)
+	if err != nil {
+		return nil, -1, err
+	}
+
+	jumps, loopBackIndex := bakeJumps(expedition, routeById, expedition.Start, nil)
+	return newBakedRoute(expedition, jumps), loopBackIndex, nil
+}
+
+func loadRouteMap(expedition *models.Expedition) (map[string]*models.Route, error) {
 	routes, err := expedition.LoadRoutes()
 	if err != nil {
-		return nil, -1, fmt.Errorf("Failed to load expedition routes: %s", err.Error())
+		return nil, fmt.Errorf("Failed to load expedition routes: %s", err.Error())
 	}
 
 	routeById := make(map[string]*models.Route, len(expedition.Routes))
@@ -442,10 +490,25 @@ func bakeExpeditionRoute(expedition *models.Expedition) (*models.Route, int, err
 		routeById[route.ID] = route
 	}
 
-	newRouteJumps := make([]models.RouteJump, 0, 64)
+	return routeById, nil
+}
+
+// bakeJumps walks the routes from start, following the default links, until
+// the end of a route or until it comes back to a system it already visited.
+// prefix holds the jumps already baked before start, ending in the start
+// system, nil when baking from the expedition start. Returns the prefix
+// followed by the walked jumps and where the loop goes back to, -1 without a
+// loop.
+func bakeJumps(expedition *models.Expedition, routeById map[string]*models.Route, start *models.RoutePosition, prefix []models.RouteJump) ([]models.RouteJump, int) {
+	newRouteJumps := make([]models.RouteJump, 0, len(prefix)+64)
+	for i := range prefix {
+		newRouteJumps = append(newRouteJumps, *prefix[i].Clone())
+	}
+	// The walk starts on the last jump of the prefix
+	offset := max(len(prefix)-1, 0)
 	loopBackIndex := -1
 
-	next := expedition.Start.Clone()
+	next := start.Clone()
 	visited := make([]*models.RouteJump, 0, 64)
 	for next != nil {
 		currentRoute, ok := routeById[next.RouteID]
@@ -461,6 +524,17 @@ func bakeExpeditionRoute(expedition *models.Expedition) (*models.Route, int, err
 		}
 		currentJump := &currentRoute.Jumps[next.JumpIndex]
 		if i := slices.Index(visited, currentJump); i > -1 {
+			loopBackIndex = i + offset
+			break
+		}
+		// The prefix was baked from other routes, only the system tells
+		// whether the walk went back into it. Like a link back into a visited
+		// route, the system is baked once more to end the lap and the loop
+		// starts over at the joint.
+		if i := slices.IndexFunc(
+			prefix[:offset],
+			func(j models.RouteJump) bool { return j.SystemID == currentJump.SystemID },
+		); i > -1 && len(visited) > 0 && newRouteJumps[len(newRouteJumps)-1].SystemID == currentJump.SystemID {
 			loopBackIndex = i
 			break
 		}
@@ -469,13 +543,13 @@ func bakeExpeditionRoute(expedition *models.Expedition) (*models.Route, int, err
 		// Because links connect two identical systems, we expect two identical
 		// systems in a row at a link joint; skip the duplicate but keep walking.
 		// (A `continue` here would freeze `next` and cause a false loop-back.)
-		if len(visited) < 2 || visited[len(visited)-2].SystemID != currentJump.SystemID {
+		if len(newRouteJumps) == 0 || newRouteJumps[len(newRouteJumps)-1].SystemID != currentJump.SystemID {
 			newRouteJumps = append(newRouteJumps, *currentJump.Clone())
 		}
 
 		link := slice.Find(
 			expedition.Links,
-			func(l models.Link) bool { return l.From.Equal(next) },
+			func(l models.Link) bool { return !l.Alternative && l.From.Equal(next) },
 		)
 		if link != nil {
 			next = link.To.Clone()
@@ -486,6 +560,10 @@ func bakeExpeditionRoute(expedition *models.Expedition) (*models.Route, int, err
 		}
 	}
 
+	return newRouteJumps, loopBackIndex
+}
+
+func newBakedRoute(expedition *models.Expedition, jumps []models.RouteJump) *models.Route {
 	return &models.Route{
 		ID:      uuid.NewString(),
 		Name:    fmt.Sprintf("Baked route for expedition: %s", expedition.Name),
@@ -494,9 +572,9 @@ func bakeExpeditionRoute(expedition *models.Expedition) (*models.Route, int, err
 			"expedition_id": expedition.ID,
 		},
 		PlotterMetadata: nil,
-		Jumps:           newRouteJumps,
+		Jumps:           jumps,
 		CreatedAt:       time.Now(),
-	}, loopBackIndex, nil
+	}
 }
 
 func ensureExpeditionCanBeStarted(expedition *models.Expedition) error {
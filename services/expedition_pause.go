@@ -0,0 +1,307 @@
+package services
+
+import (
+	"ed-expedition/database"
+	"ed-expedition/lib/slice"
+	"ed-expedition/models"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+)
+
+// PauseExpedition takes a break from the active expedition. It is no longer
+// active, so jumps are not recorded until it is resumed.
+func (e *ExpeditionService) PauseExpedition() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.activeExpedition == nil {
+		return errors.New("There is no active expedition to pause")
+	}
+
+	expedition := e.activeExpedition
+	expeditionSummary := slice.Find(
+		e.Index.Expeditions,
+		func(exp models.ExpeditionSummary) bool { return exp.ID == expedition.ID },
+	)
+	if expeditionSummary == nil {
+		return errors.New("Unable to find active expedition summary")
+	}
+
+	prevPauses := expedition.Pauses
+	prevLastUpdated := expedition.LastUpdated
+	undo := func() {
+		expedition.Pauses = prevPauses
+		expedition.LastUpdated = prevLastUpdated
+		expedition.Status = models.StatusActive
+
+		expeditionSummary.Status = models.StatusActive
+		expeditionSummary.LastUpdated = prevLastUpdated
+		e.Index.ActiveExpeditionID = &expedition.ID
+	}
+
+	now := time.Now()
+	expedition.Pauses = append(slices.Clone(expedition.Pauses), models.Pause{From: now})
+	expedition.LastUpdated = now
+	expedition.Status = models.StatusPaused
+
+	expeditionSummary.Status = models.StatusPaused
+	expeditionSummary.LastUpdated = now
+	e.Index.ActiveExpeditionID = nil
+
+	t := database.NewTransaction("ExpeditionService.PauseExpedition")
+
+	if err := models.TSaveExpedition(t, expedition); err != nil {
+		undo()
+		return fmt.Errorf("Failed to save expedition: %s", err.Error())
+	}
+
+	if err := models.TSaveIndex(t, e.Index); err != nil {
+		undo()
+		if rErr := t.Rewind(); rErr != nil {
+			e.logger.Error("[ExpeditionService] PauseExpedition transaction rewind failed.")
+		}
+		return fmt.Errorf("Failed to save index: %s", err.Error())
+	}
+
+	if err := t.Apply(); err != nil {
+		undo()
+		e.logger.Error("[ExpeditionService] PauseExpedition transaction failed to apply.")
+		return fmt.Errorf("Failed to pause expedition: %s", err.Error())
+	}
+
+	e.activeExpedition = nil
+	e.bakedRoute = nil
+	e.currentJump = nil
+
+	return nil
+}
+
+// ResumeExpedition makes a paused expedition active again. When the commander
+// is somewhere on the baked route tracking picks up from there, otherwise the
+// next jumps are detours until they find their way back.
+func (e *ExpeditionService) ResumeExpedition(expeditionId string, currentSystemId *int64, commanderFID string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.activeExpedition != nil {
+		return errors.New("Pause or end the active expedition first")
+	}
+
+	expeditionSummary := slice.Find(
+		e.Index.Expeditions,
+		func(exp models.ExpeditionSummary) bool { return exp.ID == expeditionId },
+	)
+	if expeditionSummary == nil {
+		return errors.New("Failed to find this expedition in the index")
+	}
+
+	expedition, err := expeditionSummary.LoadFull()
+	if err != nil {
+		return fmt.Errorf("Failed to load expedition: %s", err.Error())
+	}
+	if expedition.Status != models.StatusPaused {
+		return errors.New("The expedition is not paused")
+	}
+	if commanderFID != "" && expedition.CommanderFID != "" && expedition.CommanderFID != commanderFID {
+		return errors.New("Cannot resume expedition: it belongs to another commander")
+	}
+	if parkedId, ok := e.Index.ParkedExpeditions[commanderFID]; ok && parkedId != expedition.ID {
+		return errors.New("Cannot resume expedition: the commander has an active expedition, log in to end it first")
+	}
+
+	route, err := expedition.LoadBaked()
+	if err != nil {
+		return fmt.Errorf("Failed to load baked route: %s", err.Error())
+	}
+
+	prevPauses := expedition.Pauses
+	prevHistory := expedition.JumpHistory
+	prevCurrentBakedIndex := expedition.CurrentBakedIndex
+	prevLaps := expedition.Laps
+	prevExpeditionLastUpdated := expedition.LastUpdated
+	prevActiveExpeditionId := e.Index.ActiveExpeditionID
+	prevParked := e.Index.ParkedExpeditions
+	prevLastUpdated := expeditionSummary.LastUpdated
+	undo := func() {
+		expedition.Pauses = prevPauses
+		expedition.JumpHistory = prevHistory
+		expedition.CurrentBakedIndex = prevCurrentBakedIndex
+		expedition.Laps = prevLaps
+		expedition.LastUpdated = prevExpeditionLastUpdated
+		expedition.Status = models.StatusPaused
+
+		e.Index.ActiveExpeditionID = prevActiveExpeditionId
+		e.Index.ParkedExpeditions = prevParked
+		expeditionSummary.Status = models.StatusPaused
+		expeditionSummary.LastUpdated = prevLastUpdated
+	}
+
+	now := time.Now()
+	if len(expedition.Pauses) > 0 {
+		expedition.Pauses = slices.Clone(expedition.Pauses)
+		expedition.Pauses[len(expedition.Pauses)-1].To = now
+	}
+
+	if currentSystemId != nil {
+		if index, ok := resumeIndex(route, expedition, *currentSystemId); ok {
+			e.logger.Info(fmt.Sprintf("[ExpeditionService](Pause) Resuming in %s at index %d", route.Jumps[index].SystemName, index))
+			expedition.CurrentBakedIndex = index
+			expedition.JumpHistory = append(slices.Clip(expedition.JumpHistory), models.JumpHistoryEntry{
+				Timestamp:  now,
+				SystemName: route.Jumps[index].SystemName,
+				SystemID:   *currentSystemId,
+				BakedIndex: &index,
+
+				Expected:  true,
+				Synthetic: false,
+				Resumed:   true,
+			})
+			if wrapLoop(expedition, route) {
+				e.logger.Info("[ExpeditionService](Pause) Resumed at the end of a lap, starting the next one")
+			}
+		} else {
+			e.logger.Info("[ExpeditionService](Pause) Resuming off route")
+		}
+	}
+
+	expedition.LastUpdated = now
+	expedition.Status = models.StatusActive
+
+	parked := maps.Clone(e.Index.ParkedExpeditions)
+	maps.DeleteFunc(parked, func(_ string, id string) bool { return id == expedition.ID })
+	if len(parked) == 0 {
+		parked = nil
+	}
+	e.Index.ParkedExpeditions = parked
+	e.Index.ActiveExpeditionID = &expedition.ID
+	expeditionSummary.Status = models.StatusActive
+	expeditionSummary.LastUpdated = now
+
+	t := database.NewTransaction("ExpeditionService.ResumeExpedition")
+
+	if err := models.TSaveExpedition(t, expedition); err != nil {
+		undo()
+		return fmt.Errorf("Failed to save expedition: %s", err.Error())
+	}
+
+	if err := models.TSaveIndex(t, e.Index); err != nil {
+		undo()
+		if rErr := t.Rewind(); rErr != nil {
+			e.logger.Error("[ExpeditionService] ResumeExpedition transaction rewind failed.")
+		}
+		return fmt.Errorf("Failed to save index: %s", err.Error())
+	}
+
+	if err := t.Apply(); err != nil {
+		undo()
+		e.logger.Error("[ExpeditionService] ResumeExpedition transaction failed to apply.")
+		return fmt.Errorf("Failed to resume expedition: %s", err.Error())
+	}
+
+	e.activeExpedition = expedition
+	e.bakedRoute = route
+	e.currentJump = nil
+	if len(expedition.JumpHistory) > 0 {
+		last := &expedition.JumpHistory[len(expedition.JumpHistory)-1]
+		if currentSystemId != nil && last.SystemID == *currentSystemId {
+			e.currentJump = last
+			e.CurrentJump.Publish(e.currentJump)
+		}
+	}
+
+	// Resumed at the end of the route
+	if atRouteEnd(expedition, route) {
+		return e.completeActiveExpedition()
+	}
+
+	return nil
+}
+
+// EndPausedExpedition ends an expedition while it is paused, closing the
+// pause. Use EndActiveExpedition for the active one.
+func (e *ExpeditionService) EndPausedExpedition(expeditionId string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	expeditionSummary := slice.Find(
+		e.Index.Expeditions,
+		func(exp models.ExpeditionSummary) bool { return exp.ID == expeditionId },
+	)
+	if expeditionSummary == nil {
+		return errors.New("Failed to find this expedition in the index")
+	}
+
+	expedition, err := expeditionSummary.LoadFull()
+	if err != nil {
+		return fmt.Errorf("Failed to load expedition: %s", err.Error())
+	}
+	if expedition.Status != models.StatusPaused {
+		return errors.New("The expedition is not paused")
+	}
+
+	route, err := expedition.LoadBaked()
+	if err != nil {
+		return fmt.Errorf("Failed to load baked route: %s", err.Error())
+	}
+
+	now := time.Now()
+	if len(expedition.Pauses) > 0 {
+		expedition.Pauses[len(expedition.Pauses)-1].To = now
+	}
+	expedition.EndedOn = now
+	expedition.LastUpdated = now
+	expedition.Status = models.StatusEnded
+	expedition.Stats = computeExpeditionStats(expedition, route)
+
+	prevLastUpdated := expeditionSummary.LastUpdated
+	undo := func() {
+		expeditionSummary.Status = models.StatusPaused
+		expeditionSummary.LastUpdated = prevLastUpdated
+	}
+
+	expeditionSummary.Status = models.StatusEnded
+	expeditionSummary.LastUpdated = now
+
+	t := database.NewTransaction("ExpeditionService.EndPausedExpedition")
+
+	if err := models.TSaveExpedition(t, expedition); err != nil {
+		undo()
+		return fmt.Errorf("Failed to save expedition: %s", err.Error())
+	}
+
+	if err := models.TSaveIndex(t, e.Index); err != nil {
+		undo()
+		if rErr := t.Rewind(); rErr != nil {
+			e.logger.Error("[ExpeditionService] EndPausedExpedition transaction rewind failed.")
+		}
+		return fmt.Errorf("Failed to save index: %s", err.Error())
+	}
+
+	if err := t.Apply(); err != nil {
+		undo()
+		e.logger.Error("[ExpeditionService] EndPausedExpedition transaction failed to apply.")
+		return fmt.Errorf("Failed to end expedition: %s", err.Error())
+	}
+
+	return nil
+}
+
+// resumeIndex finds the system on the baked route, preferring the first
+// occurrence at or after the current index over one behind it
+func resumeIndex(route *models.Route, expedition *models.Expedition, systemId int64) (int, bool) {
+	from := max(expedition.CurrentBakedIndex, 0)
+	for i := from; i < len(route.Jumps); i++ {
+		if route.Jumps[i].SystemID == systemId {
+			return i, true
+		}
+	}
+	for i := from - 1; i >= 0; i-- {
+		if route.Jumps[i].SystemID == systemId {
+			return i, true
+		}
+	}
+	return -1, false
+}
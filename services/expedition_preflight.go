@@ -0,0 +1,91 @@
+package services
+
+import (
+	"ed-expedition/models"
+	"errors"
+	"fmt"
+)
+
+// FeasibilityChecker checks a baked route against the ship. The plotters
+// depend on the services, so the app provides one with SetFeasibilityChecker.
+type FeasibilityChecker func(route *models.Route) (*models.FeasibilityReport, error)
+
+func (e *ExpeditionService) SetFeasibilityChecker(checker FeasibilityChecker) {
+	e.preflightMu.Lock()
+	defer e.preflightMu.Unlock()
+	e.feasibilityChecker = checker
+}
+
+// SetPreflightPolicy sets whether a route that is not feasible keeps the
+// expedition from being started
+func (e *ExpeditionService) SetPreflightPolicy(policy models.PreflightPolicy) {
+	e.preflightMu.Lock()
+	defer e.preflightMu.Unlock()
+	e.preflightPolicy = policy
+}
+
+// PreflightCheck bakes the route of a planned expedition, without saving it,
+// and checks it can be flown with the current ship
+func (e *ExpeditionService) PreflightCheck(expeditionId string) (*models.FeasibilityReport, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	expedition, err := models.LoadExpedition(expeditionId)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to load expedition: %s", err.Error())
+	}
+	if err := ensureExpeditionCanBeStarted(expedition); err != nil {
+		return nil, fmt.Errorf("Cannot check expedition: %s", err.Error())
+	}
+
+	route, _, err := bakeExpeditionRoute(expedition)
+	if err != nil {
+		return nil, err
+	}
+	return e.checkFeasibility(route)
+}
+
+func (e *ExpeditionService) checkFeasibility(route *models.Route) (*models.FeasibilityReport, error) {
+	e.preflightMu.Lock()
+	checker := e.feasibilityChecker
+	e.preflightMu.Unlock()
+
+	if checker == nil {
+		return nil, errors.New("There is no feasibility check available")
+	}
+	return checker(route)
+}
+
+// preflight runs the check on the baked route when starting an expedition.
+// Only errors under the block policy stop the start, a check that can not run
+// (e.g. without a known loadout) does not.
+func (e *ExpeditionService) preflight(route *models.Route) error {
+	e.preflightMu.Lock()
+	enabled := e.feasibilityChecker != nil
+	policy := e.preflightPolicy
+	e.preflightMu.Unlock()
+	if !enabled {
+		return nil
+	}
+
+	report, err := e.checkFeasibility(route)
+	if err != nil {
+		e.logger.Warning(fmt.Sprintf("[ExpeditionService](Preflight) Skipping the check: %s", err.Error()))
+		return nil
+	}
+	if !report.Blocking {
+		return nil
+	}
+
+	for _, issue := range report.Issues {
+		if issue.Severity != models.FeasibilityError {
+			continue
+		}
+		if policy == models.PreflightWarn {
+			e.logger.Warning(fmt.Sprintf("[ExpeditionService](Preflight) %s", issue.Message))
+			continue
+		}
+		return fmt.Errorf("The route is not feasible with the current ship: %s", issue.Message)
+	}
+	return nil
+}
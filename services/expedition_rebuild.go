@@ -0,0 +1,418 @@
+package services
+
+import (
+	"ed-expedition/database"
+	"ed-expedition/journal"
+	"ed-expedition/lib/slice"
+	"ed-expedition/lib/vec"
+	"ed-expedition/models"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// A jump that starts further than this (in light years) from where the
+// previous one landed means journal data is missing in between.
+const rebuildGapTolerance = 0.5
+
+type HistoryDiffKind string
+
+const (
+	HistoryDiffAdded   HistoryDiffKind = "added"
+	HistoryDiffRemoved HistoryDiffKind = "removed"
+	HistoryDiffChanged HistoryDiffKind = "changed"
+)
+
+var AllHistoryDiffKind = []struct {
+	Value  HistoryDiffKind
+	TSName string
+}{
+	{HistoryDiffAdded, "ADDED"},
+	{HistoryDiffRemoved, "REMOVED"},
+	{HistoryDiffChanged, "CHANGED"},
+}
+
+type HistoryDiffEntry struct {
+	Kind HistoryDiffKind          `json:"kind"`
+	Old  *models.JumpHistoryEntry `json:"old,omitempty"`
+	New  *models.JumpHistoryEntry `json:"new,omitempty"`
+}
+
+// HistoryRebuild is a jump history reconstructed from the journals, waiting to
+// be reviewed and applied with ApplyHistoryRebuild.
+type HistoryRebuild struct {
+	ExpeditionID      string                    `json:"expedition_id"`
+	JumpHistory       []models.JumpHistoryEntry `json:"jump_history"`
+	CurrentBakedIndex int                       `json:"current_baked_index"`
+	// Laps of a looping route according to the rebuilt history
+	Laps []models.Lap `json:"laps,omitempty"`
+	// Gaps in the journals bridged with synthetic jumps along the route
+	FilledGaps int `json:"filled_gaps"`
+	// Gaps that could not be bridged since they were off route
+	UnfilledGaps int                `json:"unfilled_gaps"`
+	Diff         []HistoryDiffEntry `json:"diff"`
+
+	// LastUpdated of the expedition the rebuild is based on, to detect jumps
+	// recorded while the rebuild was being reviewed.
+	basedOn time.Time
+}
+
+// RebuildHistory reads every journal between the expedition's start and end
+// and replays the jumps against the baked route. Nothing is written, the
+// result holds a diff against the current history and is kept until applied
+// with ApplyHistoryRebuild.
+func (e *ExpeditionService) RebuildHistory(expeditionId string) (*HistoryRebuild, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.watcher == nil {
+		return nil, errors.New("No journal directory is being watched")
+	}
+
+	expedition, err := e.loadExpedition(expeditionId)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to load expedition: %s", err.Error())
+	}
+	if expedition.StartedOn.IsZero() || expedition.BakedRouteID == nil {
+		return nil, errors.New("The expedition has not been started, there is no history to rebuild")
+	}
+	// The journals know nothing of them, deleted jumps would come back and
+	// skipped ones would be gone
+	if len(expedition.Corrections) > 0 {
+		return nil, errors.New("The expedition has manual corrections, rebuilding the history from the journals would undo them")
+	}
+
+	route, err := expedition.LoadBaked()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to load baked route: %s", err.Error())
+	}
+
+	// Journal timestamps only have second precision
+	since := expedition.StartedOn.Truncate(time.Second)
+	jumps, err := journal.ReadJumps(e.watcher.Dir(), since, expedition.EndedOn, e.logger)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read journals: %s", err.Error())
+	}
+	e.logger.Info(fmt.Sprintf("[ExpeditionService](Rebuild) found %d jumps for expedition %s", len(jumps), expedition.ID))
+
+	rebuild := rebuildJumpHistory(expedition, route, jumps, e.passengerJumps)
+	rebuild.Diff = diffJumpHistory(expedition.JumpHistory, rebuild.JumpHistory)
+	rebuild.basedOn = expedition.LastUpdated
+
+	e.pendingRebuild = rebuild
+	return rebuild, nil
+}
+
+// ApplyHistoryRebuild writes the history from the last RebuildHistory call for
+// the expedition.
+func (e *ExpeditionService) ApplyHistoryRebuild(expeditionId string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	rebuild := e.pendingRebuild
+	if rebuild == nil || rebuild.ExpeditionID != expeditionId {
+		return errors.New("There is no rebuilt history to apply for this expedition")
+	}
+
+	expedition, err := e.loadExpedition(expeditionId)
+	if err != nil {
+		return fmt.Errorf("Failed to load expedition: %s", err.Error())
+	}
+	if !expedition.LastUpdated.Equal(rebuild.basedOn) {
+		return errors.New("The expedition changed since the history was rebuilt, rebuild it again")
+	}
+
+	expeditionSummary := slice.Find(
+		e.Index.Expeditions,
+		func(s models.ExpeditionSummary) bool { return s.ID == expeditionId },
+	)
+	if expeditionSummary == nil {
+		return errors.New("Unable to find expedition summary")
+	}
+
+	prevHistory := expedition.JumpHistory
+	prevBakedIndex := expedition.CurrentBakedIndex
+	prevLaps := expedition.Laps
+	prevLastUpdated := expedition.LastUpdated
+	prevSummary := *expeditionSummary
+	undo := func() {
+		expedition.JumpHistory = prevHistory
+		expedition.CurrentBakedIndex = prevBakedIndex
+		expedition.Laps = prevLaps
+		expedition.LastUpdated = prevLastUpdated
+		*expeditionSummary = prevSummary
+	}
+
+	expedition.JumpHistory = rebuild.JumpHistory
+	expedition.CurrentBakedIndex = rebuild.CurrentBakedIndex
+	expedition.Laps = rebuild.Laps
+	expedition.LastUpdated = time.Now()
+	expeditionSummary.LastUpdated = expedition.LastUpdated
+	expeditionSummary.FuelScooped, expeditionSummary.ScoopDuration = scoopTotals(expedition.JumpHistory)
+
+	t := database.NewTransaction("ExpeditionService.ApplyHistoryRebuild")
+
+	if err := models.TSaveExpedition(t, expedition); err != nil {
+		undo()
+		return fmt.Errorf("Failed to save expedition: %s", err.Error())
+	}
+
+	if err := models.TSaveIndex(t, e.Index); err != nil {
+		undo()
+		if rErr := t.Rewind(); rErr != nil {
+			e.logger.Error("[ExpeditionService] ApplyHistoryRebuild transaction rewind failed after save index.")
+		}
+		return fmt.Errorf("Failed to save index: %s", err.Error())
+	}
+
+	if err := t.Apply(); err != nil {
+		undo()
+		e.logger.Error("[ExpeditionService] ApplyHistoryRebuild transaction failed to apply.")
+		return fmt.Errorf("Failed to apply rebuilt history: %s", err.Error())
+	}
+
+	e.pendingRebuild = nil
+
+	if expedition != e.activeExpedition {
+		return nil
+	}
+
+	e.currentJump = nil
+	if len(expedition.JumpHistory) > 0 {
+		e.currentJump = &expedition.JumpHistory[len(expedition.JumpHistory)-1]
+		e.CurrentJump.Publish(e.currentJump)
+	}
+
+	// The journals may show we actually made it to the end
+	if atRouteEnd(expedition, e.bakedRoute) {
+		return e.completeActiveExpedition()
+	}
+
+	return nil
+}
+
+// loadExpedition returns the in-memory active expedition when it is the one
+// asked for, so we don't work on a stale copy.
+func (e *ExpeditionService) loadExpedition(expeditionId string) (*models.Expedition, error) {
+	if e.activeExpedition != nil && e.activeExpedition.ID == expeditionId {
+		return e.activeExpedition, nil
+	}
+	return models.LoadExpedition(expeditionId)
+}
+
+func rebuildJumpHistory(
+	expedition *models.Expedition,
+	route *models.Route,
+	jumps []*journal.FSDJumpEvent,
+	passengerJumps models.PassengerJumpPolicy,
+) *HistoryRebuild {
+	rebuild := &HistoryRebuild{
+		ExpeditionID:      expedition.ID,
+		JumpHistory:       make([]models.JumpHistoryEntry, 0, len(jumps)+1),
+		CurrentBakedIndex: -1,
+	}
+
+	var prevPos *vec.Vec3
+	var prevTime time.Time
+
+	// The start system entry is written when the expedition is started, not
+	// from a journal event, so it is carried over as is.
+	if len(expedition.JumpHistory) > 0 {
+		first := expedition.JumpHistory[0]
+		if first.BakedIndex != nil && *first.BakedIndex == 0 && !first.Timestamp.After(expedition.StartedOn) {
+			rebuild.JumpHistory = append(rebuild.JumpHistory, first)
+			rebuild.CurrentBakedIndex = 0
+			prevPos = route.Jumps[0].Position
+			prevTime = first.Timestamp
+		}
+	}
+
+	// Deaths and resumes are not in the jumps either, they are carried over in
+	// order
+	deaths := slices.DeleteFunc(slices.Clone(expedition.JumpHistory), func(h models.JumpHistoryEntry) bool { return h.Death == nil && !h.Resumed })
+	addDeathsBefore := func(ts time.Time) {
+		for len(deaths) > 0 && deaths[0].Timestamp.Before(ts) {
+			death := deaths[0]
+			deaths = deaths[1:]
+			rebuild.JumpHistory = append(rebuild.JumpHistory, death)
+			if death.BakedIndex != nil {
+				rebuild.CurrentBakedIndex = *death.BakedIndex
+			}
+			// Respawning or resuming is not a jump, there is no gap to fill after it
+			prevPos = nil
+		}
+	}
+
+	for _, event := range jumps {
+		if rebuild.CurrentBakedIndex >= len(route.Jumps)-1 {
+			break
+		}
+		addDeathsBefore(event.Timestamp)
+		if event.CommanderFID != "" && expedition.CommanderFID != "" && event.CommanderFID != expedition.CommanderFID {
+			continue
+		}
+
+		var pos *vec.Vec3
+		if len(event.StarPos) == 3 {
+			p := vec.NewVec3FromSlice(event.StarPos)
+			pos = &p
+		}
+
+		if event.Carrier && !expedition.CountCarrierJumps || expedition.IsPausedAt(event.Timestamp) {
+			// Not progress, but the commander did move
+			prevPos, prevTime = pos, event.Timestamp
+			continue
+		}
+
+		if !event.InOwnShip() {
+			if passengerJumps == models.PassengerJumpRecord {
+				rebuild.JumpHistory = append(rebuild.JumpHistory, passengerJumpEntry(event))
+			}
+			prevPos, prevTime = pos, event.Timestamp
+			continue
+		}
+
+		if prevPos != nil && pos != nil && prevPos.Distance(*pos) > event.JumpDist+rebuildGapTolerance {
+			if rebuild.fillGap(route, prevTime, event) {
+				rebuild.FilledGaps++
+			} else {
+				rebuild.UnfilledGaps++
+			}
+		}
+
+		entry, bakedIndex := matchJumpToRoute(route, rebuild.CurrentBakedIndex, event)
+		rebuild.CurrentBakedIndex = bakedIndex
+		if old := findHistoryEntry(expedition.JumpHistory, &entry); old != nil {
+			entry.Exploration = old.Exploration
+			entry.FuelScoop = old.FuelScoop
+			entry.Health = old.Health
+		}
+		rebuild.JumpHistory = append(rebuild.JumpHistory, entry)
+
+		if rebuild.CurrentBakedIndex >= len(route.Jumps)-1 && expedition.BakedLoopBackIndex != nil {
+			lapped := *expedition
+			lapped.JumpHistory = rebuild.JumpHistory
+			lapped.Laps = computeLaps(&lapped, route)
+			if !lapped.LapsDone() {
+				rebuild.CurrentBakedIndex = *expedition.BakedLoopBackIndex
+			}
+		}
+		prevPos, prevTime = pos, event.Timestamp
+	}
+	if rebuild.CurrentBakedIndex < len(route.Jumps)-1 {
+		addDeathsBefore(time.Now())
+	}
+
+	rebuilt := *expedition
+	rebuilt.JumpHistory = rebuild.JumpHistory
+	rebuild.Laps = computeLaps(&rebuilt, route)
+
+	return rebuild
+}
+
+// fillGap adds synthetic jumps for the part of the route between where we last
+// were and where the event landed. Only possible when both ends are on route.
+func (r *HistoryRebuild) fillGap(route *models.Route, prevTime time.Time, event *journal.FSDJumpEvent) bool {
+	if r.CurrentBakedIndex < 0 {
+		return false
+	}
+
+	landedIndex := -1
+	for i := r.CurrentBakedIndex + 2; i < len(route.Jumps); i++ {
+		if route.Jumps[i].SystemID == event.SystemAddress {
+			landedIndex = i
+			break
+		}
+	}
+	if landedIndex < 0 {
+		return false
+	}
+
+	missing := landedIndex - r.CurrentBakedIndex - 1
+	step := event.Timestamp.Sub(prevTime) / time.Duration(missing+1)
+	for n := 1; n <= missing; n++ {
+		entry := syntheticJump(route, r.CurrentBakedIndex+n, prevTime.Add(step*time.Duration(n)))
+		r.JumpHistory = append(r.JumpHistory, entry)
+	}
+	r.CurrentBakedIndex = landedIndex - 1
+
+	return true
+}
+
+// syntheticJump is a jump to the system at bakedIndex that is not in the
+// journals, with the distance and fuel the route planned for it
+func syntheticJump(route *models.Route, bakedIndex int, timestamp time.Time) models.JumpHistoryEntry {
+	jump := route.Jumps[bakedIndex]
+	entry := models.JumpHistoryEntry{
+		Timestamp:  timestamp,
+		SystemName: jump.SystemName,
+		SystemID:   jump.SystemID,
+		BakedIndex: &bakedIndex,
+
+		Distance: jump.Distance,
+
+		Expected:  true,
+		Synthetic: true,
+	}
+	if jump.FuelUsed != nil {
+		entry.FuelUsed = *jump.FuelUsed
+	}
+	if jump.FuelInTank != nil {
+		entry.FuelLevel = *jump.FuelInTank
+	}
+	return entry
+}
+
+func findHistoryEntry(history []models.JumpHistoryEntry, entry *models.JumpHistoryEntry) *models.JumpHistoryEntry {
+	return slice.Find(history, func(h models.JumpHistoryEntry) bool {
+		return h.SystemID == entry.SystemID && h.Timestamp.Equal(entry.Timestamp)
+	})
+}
+
+func diffJumpHistory(old, new []models.JumpHistoryEntry) []HistoryDiffEntry {
+	diff := make([]HistoryDiffEntry, 0)
+
+	for i := range new {
+		n := &new[i]
+		o := findHistoryEntry(old, n)
+		switch {
+		case o == nil:
+			diff = append(diff, HistoryDiffEntry{Kind: HistoryDiffAdded, New: n})
+		case !sameJump(o, n):
+			diff = append(diff, HistoryDiffEntry{Kind: HistoryDiffChanged, Old: o, New: n})
+		}
+	}
+	for i := range old {
+		o := &old[i]
+		if findHistoryEntry(new, o) == nil {
+			diff = append(diff, HistoryDiffEntry{Kind: HistoryDiffRemoved, Old: o})
+		}
+	}
+
+	slices.SortStableFunc(diff, func(a, b HistoryDiffEntry) int {
+		return diffTime(a).Compare(diffTime(b))
+	})
+	return diff
+}
+
+func diffTime(d HistoryDiffEntry) time.Time {
+	if d.New != nil {
+		return d.New.Timestamp
+	}
+	return d.Old.Timestamp
+}
+
+func sameJump(a, b *models.JumpHistoryEntry) bool {
+	sameBakedIndex := (a.BakedIndex == nil && b.BakedIndex == nil) ||
+		(a.BakedIndex != nil && b.BakedIndex != nil && *a.BakedIndex == *b.BakedIndex)
+
+	return sameBakedIndex &&
+		a.SystemName == b.SystemName &&
+		a.Distance == b.Distance &&
+		a.FuelUsed == b.FuelUsed &&
+		a.FuelLevel == b.FuelLevel &&
+		a.Expected == b.Expected &&
+		a.Synthetic == b.Synthetic &&
+		a.OffExpedition == b.OffExpedition
+}
@@ -0,0 +1,234 @@
+package services
+
+import (
+	"ed-expedition/lib/vec"
+	"ed-expedition/models"
+	"fmt"
+	"math"
+)
+
+// RejoinPlotter plots a route between two systems. The plotters depend on the
+// services, so the app provides one with SetRejoinPlotter.
+type RejoinPlotter func(from, to string) (*models.Route, error)
+
+// SystemsAround returns the systems in the galaxy database around a position.
+// Used to find route systems that were plotted without a position.
+type SystemsAround func(pos vec.Vec3, radius float64) ([]*GalaxySystem, error)
+
+// Route systems without a position are only looked up this far around the
+// commander, in ly
+const rejoinLookupRadius = 100.0
+
+// RejoinSuggestion is a route from off route back to the baked route.
+// Published when plotted and as the commander follows it, and with a nil
+// Route once they are back on the baked route.
+type RejoinSuggestion struct {
+	Route *models.Route `json:"route,omitempty"`
+	// Index in the baked route the route leads back to
+	TargetIndex int `json:"targetIndex"`
+	// System to jump to next, empty when there is no rejoin route
+	NextSystem string `json:"nextSystem"`
+}
+
+type rejoinState struct {
+	expeditionID string
+	route        *models.Route
+	targetIndex  int
+	// Index in route of the system the commander is in
+	index int
+}
+
+func (r *rejoinState) suggestion() *RejoinSuggestion {
+	suggestion := &RejoinSuggestion{Route: r.route, TargetIndex: r.targetIndex}
+	if r.index+1 < len(r.route.Jumps) {
+		suggestion.NextSystem = r.route.Jumps[r.index+1].SystemName
+	}
+	return suggestion
+}
+
+// SetRejoinAfter sets how many jumps off route it takes before a route back is
+// plotted, 0 disables rejoin plotting
+func (e *ExpeditionService) SetRejoinAfter(jumps int) {
+	e.rejoinMu.Lock()
+	defer e.rejoinMu.Unlock()
+	e.rejoinAfter = jumps
+}
+
+func (e *ExpeditionService) SetRejoinPlotter(plotter RejoinPlotter) {
+	e.rejoinMu.Lock()
+	defer e.rejoinMu.Unlock()
+	e.rejoinPlotter = plotter
+}
+
+func (e *ExpeditionService) SetSystemsAround(lookup SystemsAround) {
+	e.rejoinMu.Lock()
+	defer e.rejoinMu.Unlock()
+	e.systemsAround = lookup
+}
+
+// trackRejoin is called after every recorded jump. Once enough jumps in a row
+// are off route it plots a route to the nearest upcoming system on the baked
+// route, and follows the commander along it.
+func (e *ExpeditionService) trackRejoin(entry *models.JumpHistoryEntry, starPos []float64) {
+	if e.activeExpedition == nil || e.bakedRoute == nil {
+		return
+	}
+
+	e.rejoinMu.Lock()
+	defer e.rejoinMu.Unlock()
+
+	if e.rejoin != nil && e.rejoin.expeditionID != e.activeExpedition.ID {
+		e.rejoin = nil
+	}
+
+	if entry.BakedIndex != nil {
+		// Back on the route, anything still plotting is stale
+		e.rejoinGeneration++
+		if e.rejoin != nil {
+			e.logger.Info("[ExpeditionService](Rejoin) Back on the route")
+			e.rejoin = nil
+			e.Rejoin.Publish(&RejoinSuggestion{})
+		}
+		return
+	}
+
+	if e.rejoin != nil {
+		for i := e.rejoin.index + 1; i < len(e.rejoin.route.Jumps); i++ {
+			if e.rejoin.route.Jumps[i].SystemID == entry.SystemID {
+				e.rejoin.index = i
+				e.Rejoin.Publish(e.rejoin.suggestion())
+				return
+			}
+		}
+		e.logger.Info("[ExpeditionService](Rejoin) Left the rejoin route, plotting a new one")
+		e.rejoin = nil
+	}
+
+	if e.rejoinPlotter == nil || e.rejoinAfter <= 0 || len(starPos) != 3 {
+		return
+	}
+	if offRouteJumps(e.activeExpedition.JumpHistory) < e.rejoinAfter {
+		return
+	}
+
+	pos := vec.NewVec3FromSlice(starPos)
+	positions := e.lookupPositions(e.bakedRoute, e.activeExpedition.CurrentBakedIndex, pos)
+	target, ok := nearestUpcomingJump(e.bakedRoute, e.activeExpedition.CurrentBakedIndex, pos, positions)
+	if !ok {
+		e.logger.Warning("[ExpeditionService](Rejoin) No upcoming system on the route has a known position")
+		return
+	}
+
+	e.rejoinGeneration++
+	generation := e.rejoinGeneration
+	expeditionID := e.activeExpedition.ID
+	from := entry.SystemName
+	to := e.bakedRoute.Jumps[target].SystemName
+	plot := e.rejoinPlotter
+
+	e.logger.Info(fmt.Sprintf("[ExpeditionService](Rejoin) Plotting from %s back to %s", from, to))
+	go func() {
+		route, err := plot(from, to)
+		if err != nil {
+			e.logger.Error(fmt.Sprintf("[ExpeditionService](Rejoin) Failed to plot rejoin route: %s", err.Error()))
+			return
+		}
+
+		e.rejoinMu.Lock()
+		defer e.rejoinMu.Unlock()
+		if generation != e.rejoinGeneration {
+			// The commander jumped again while plotting
+			return
+		}
+		e.rejoin = &rejoinState{expeditionID: expeditionID, route: route, targetIndex: target}
+		e.Rejoin.Publish(e.rejoin.suggestion())
+	}()
+}
+
+// rejoinNextSystem returns the next system on the rejoin route, nil without
+// one
+func (e *ExpeditionService) rejoinNextSystem() *string {
+	e.rejoinMu.Lock()
+	defer e.rejoinMu.Unlock()
+
+	if e.rejoin == nil || e.activeExpedition == nil || e.rejoin.expeditionID != e.activeExpedition.ID {
+		return nil
+	}
+	next := e.rejoin.index + 1
+	if next >= len(e.rejoin.route.Jumps) {
+		return nil
+	}
+	return &e.rejoin.route.Jumps[next].SystemName
+}
+
+// offRouteJumps counts the jumps at the end of the history that missed the
+// route. Passenger jumps and respawns are not the commander's doing.
+func offRouteJumps(history []models.JumpHistoryEntry) int {
+	count := 0
+	for i := len(history) - 1; i >= 0; i-- {
+		entry := history[i]
+		if entry.BakedIndex != nil {
+			break
+		}
+		if entry.OffExpedition {
+			continue
+		}
+		count++
+	}
+	return count
+}
+
+// lookupPositions finds the upcoming route systems without a position in the
+// galaxy database, by system id. Empty without a database or when every
+// system has a position.
+func (e *ExpeditionService) lookupPositions(route *models.Route, currentIndex int, pos vec.Vec3) map[int64]vec.Vec3 {
+	positions := map[int64]vec.Vec3{}
+	if e.systemsAround == nil {
+		return positions
+	}
+
+	missing := map[int64]bool{}
+	for i := currentIndex + 1; i < len(route.Jumps); i++ {
+		if route.Jumps[i].Position == nil {
+			missing[route.Jumps[i].SystemID] = true
+		}
+	}
+	if len(missing) == 0 {
+		return positions
+	}
+
+	systems, err := e.systemsAround(pos, rejoinLookupRadius)
+	if err != nil {
+		e.logger.Warning(fmt.Sprintf("[ExpeditionService](Rejoin) Failed to look up route systems: %s", err.Error()))
+		return positions
+	}
+	for _, system := range systems {
+		if missing[int64(system.Id)] {
+			positions[int64(system.Id)] = system.Position
+		}
+	}
+	return positions
+}
+
+// nearestUpcomingJump returns the index of the upcoming route system closest
+// to pos. Systems without a position in the route are looked for in
+// positions.
+func nearestUpcomingJump(route *models.Route, currentIndex int, pos vec.Vec3, positions map[int64]vec.Vec3) (int, bool) {
+	best := -1
+	bestDistance := math.Inf(1)
+	for i := currentIndex + 1; i < len(route.Jumps); i++ {
+		jump := route.Jumps[i]
+		position, ok := positions[jump.SystemID]
+		if jump.Position != nil {
+			position, ok = *jump.Position, true
+		}
+		if !ok {
+			continue
+		}
+		if distance := position.Distance(pos); distance < bestDistance {
+			best = i
+			bestDistance = distance
+		}
+	}
+	return best, best >= 0
+}
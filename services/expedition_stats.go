@@ -0,0 +1,145 @@
+package services
+
+import (
+	"ed-expedition/models"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// A break longer than this between two jumps starts a new play session
+const statsSessionGap = 30 * time.Minute
+
+// Time between two jumps of a session only counts toward play time up to this,
+// the rest is spent away from the game
+const statsAFKGap = 10 * time.Minute
+
+// ExpeditionStats returns the statistics of an expedition. Completed and ended
+// expeditions return the cached stats unless recompute is set, in which case
+// the cache is refreshed. Other expeditions are computed every time.
+func (e *ExpeditionService) ExpeditionStats(expeditionId string, recompute bool) (*models.ExpeditionStats, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	expedition, err := e.loadExpedition(expeditionId)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to load expedition: %s", err.Error())
+	}
+	if expedition.BakedRouteID == nil {
+		return nil, errors.New("The expedition has not been started, there are no stats")
+	}
+
+	finished := expedition.Status == models.StatusCompleted || expedition.Status == models.StatusEnded
+	if finished && expedition.Stats != nil && !recompute {
+		return expedition.Stats, nil
+	}
+
+	route := e.bakedRoute
+	if expedition != e.activeExpedition {
+		route, err = expedition.LoadBaked()
+		if err != nil {
+			return nil, fmt.Errorf("Failed to load baked route: %s", err.Error())
+		}
+	}
+
+	stats := computeExpeditionStats(expedition, route)
+	if !finished {
+		return stats, nil
+	}
+
+	prevStats := expedition.Stats
+	expedition.Stats = stats
+	if err := models.SaveExpedition(expedition); err != nil {
+		expedition.Stats = prevStats
+		return nil, fmt.Errorf("Failed to save expedition stats: %s", err.Error())
+	}
+	return stats, nil
+}
+
+func computeExpeditionStats(expedition *models.Expedition, route *models.Route) *models.ExpeditionStats {
+	stats := &models.ExpeditionStats{
+		ComputedAt: time.Now(),
+		Sessions:   []models.PlaySession{},
+	}
+
+	maxIndex := -1
+	detouring := false
+	var session *models.PlaySession
+	prevTimestamp := expedition.StartedOn
+	for i, entry := range expedition.JumpHistory {
+		if entry.Erroneous {
+			continue
+		}
+		// Time since the previous entry, a break only counts up to the AFK gap
+		var interval time.Duration
+		if !prevTimestamp.IsZero() {
+			interval = min(max(entry.Timestamp.Sub(prevTimestamp), 0), statsAFKGap)
+		}
+		prevTimestamp = entry.Timestamp
+		if entry.BakedIndex != nil {
+			maxIndex = max(maxIndex, *entry.BakedIndex)
+		}
+		if entry.Death != nil {
+			stats.Deaths++
+			continue
+		}
+		if entry.Resumed || entry.OffExpedition {
+			continue
+		}
+		// The system the expedition was started in
+		if i == 0 && entry.BakedIndex != nil && *entry.BakedIndex == 0 && !entry.Timestamp.After(expedition.StartedOn) {
+			continue
+		}
+
+		stats.Jumps++
+		stats.TotalDistance += entry.Distance
+		stats.FuelUsed += entry.FuelUsed
+		if entry.FuelScoop != nil {
+			stats.FuelScooped += entry.FuelScoop.Scooped
+		}
+		if entry.FSDBoost != nil && *entry.FSDBoost != models.FSDBoostNone {
+			stats.BoostedJumps++
+		}
+
+		if entry.BakedIndex != nil {
+			stats.OnRouteDistance += entry.Distance
+			detouring = false
+		} else {
+			if !detouring {
+				stats.Detours++
+			}
+			detouring = true
+			stats.DetourJumps++
+			stats.DetourDistance += entry.Distance
+		}
+
+		if session == nil || entry.Timestamp.Sub(session.End) > statsSessionGap {
+			stats.Sessions = append(stats.Sessions, models.PlaySession{Start: entry.Timestamp})
+			session = &stats.Sessions[len(stats.Sessions)-1]
+			// The break before a new session is not play time
+			interval = 0
+		}
+		session.End = entry.Timestamp
+		session.Jumps++
+		session.Duration += interval.Seconds()
+	}
+
+	for _, session := range stats.Sessions {
+		stats.PlayTime += session.Duration
+	}
+	if stats.Jumps > 0 {
+		stats.AverageJump = stats.TotalDistance / float64(stats.Jumps)
+	}
+	if stats.PlayTime > 0 {
+		stats.JumpsPerHour = float64(stats.Jumps) / (stats.PlayTime / 3600)
+	}
+
+	switch {
+	case expedition.Status == models.StatusCompleted:
+		stats.Completion = 100
+	case route != nil && len(route.Jumps) > 1 && maxIndex > 0:
+		stats.Completion = min(float64(maxIndex)/float64(len(route.Jumps)-1)*100, 100)
+	}
+
+	return stats
+}
@@ -4,12 +4,17 @@ import (
 	"ed-expedition/database"
 	"ed-expedition/journal"
 	"ed-expedition/lib/slice"
+	"ed-expedition/lib/vec"
+	"ed-expedition/migrations"
 	"ed-expedition/models"
+	"fmt"
 	"os"
 	"path"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -30,21 +35,34 @@ func (l *TestLogger) Fatal(message string)   {}
 
 // RecordingLogger implements wails Logger interface and records trace messages
 type RecordingLogger struct {
+	mu       sync.Mutex
 	Messages []string
 }
 
-func (l *RecordingLogger) Print(message string)   {}
-func (l *RecordingLogger) Trace(message string)   { l.Messages = append(l.Messages, message) }
+func (l *RecordingLogger) Print(message string) {}
+func (l *RecordingLogger) Trace(message string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.Messages = append(l.Messages, message)
+}
 func (l *RecordingLogger) Debug(message string)   {}
 func (l *RecordingLogger) Info(message string)    {}
 func (l *RecordingLogger) Warning(message string) {}
 func (l *RecordingLogger) Error(message string)   {}
 func (l *RecordingLogger) Fatal(message string)   {}
 
+// Traced reports whether a trace message containing text was recorded
+func (l *RecordingLogger) Traced(text string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return slices.ContainsFunc(l.Messages, func(message string) bool { return strings.Contains(message, text) })
+}
+
 type ExpeditionServiceTestSuite struct {
 	suite.Suite
 	tmpDir     string
 	watcher    *journal.Watcher
+	logger     *RecordingLogger
 	service    *ExpeditionService
 	distance   float64
 	fuelUsed   float64
@@ -76,7 +94,8 @@ func (s *ExpeditionServiceTestSuite) SetupTest() {
 	}
 
 	currentSystemId := int64(0)
-	s.service = NewExpeditionService(&TestLogger{}, currentSystemId)
+	s.logger = &RecordingLogger{}
+	s.service = NewExpeditionService(s.logger, currentSystemId)
 	s.service.SetWatcher(s.watcher)
 	s.service.Start()
 	s.watcher.Start()
@@ -87,6 +106,55 @@ func (s *ExpeditionServiceTestSuite) SetupTest() {
 	s.bakedIndex = 0
 }
 
+// waitFor waits until cond holds, it is checked while no handler is running
+func (s *ExpeditionServiceTestSuite) waitFor(cond func() bool, msgAndArgs ...any) {
+	s.T().Helper()
+	s.Require().Eventually(func() bool {
+		s.service.mu.Lock()
+		defer s.service.mu.Unlock()
+		return cond()
+	}, time.Second, 5*time.Millisecond, msgAndArgs...)
+}
+
+// waitForJumps waits until the expedition active now has n history entries,
+// it may have been completed by the last of them
+func (s *ExpeditionServiceTestSuite) waitForJumps(n int) {
+	s.T().Helper()
+	s.service.mu.Lock()
+	expedition := s.service.activeExpedition
+	s.service.mu.Unlock()
+	s.Require().NotNil(expedition, "no active expedition to wait for")
+	s.waitFor(func() bool { return len(expedition.JumpHistory) == n }, "%d jumps in the history", n)
+}
+
+// waitForTrace waits until a handler has traced a message containing text, for
+// events that are handled without changing anything
+func (s *ExpeditionServiceTestSuite) waitForTrace(text string) {
+	s.T().Helper()
+	s.waitFor(func() bool { return s.logger.Traced(text) }, "trace %q", text)
+}
+
+// createExpedition creates a planned expedition of routes joined by links
+func (s *ExpeditionServiceTestSuite) createExpedition(routes []*models.Route, links ...[2]models.RoutePosition) string {
+	s.T().Helper()
+	id, err := s.service.CreateExpedition()
+	s.Require().NoError(err)
+	for _, route := range routes {
+		s.Require().NoError(s.service.AddRouteToExpedition(id, route))
+	}
+	for _, link := range links {
+		s.Require().NoError(s.service.CreateLink(id, link[0], link[1]))
+	}
+	return id
+}
+
+// loopBackTo makes the fixture expedition loop back to index after the last
+// system, started just before the test's first jump at jumpTime
+func (s *ExpeditionServiceTestSuite) loopBackTo(index int, jumpTime time.Time) {
+	s.service.activeExpedition.BakedLoopBackIndex = &index
+	s.service.activeExpedition.StartedOn = jumpTime.Add(-time.Minute)
+}
+
 func (s *ExpeditionServiceTestSuite) TearDownTest() {
 	if s.service != nil {
 		s.service.Stop()
@@ -113,7 +181,7 @@ func (s *ExpeditionServiceTestSuite) TestCorrectlyRecordOnRouteJump() {
 	s.bakedIndex = 1
 	jumpTime := time.Date(2025, 12, 20, 10, 0, 0, 0, time.UTC)
 	simulateJump(s.T(), s.tmpDir, Jump{name: "Alpha Centauri", id: 2, distance: &s.distance, fuelUsed: &s.fuelUsed, fuelLevel: &s.fuelLevel}, jumpTime)
-	time.Sleep(10 * time.Millisecond)
+	s.waitForJumps(1)
 
 	// Assert updates
 	assert.Len(s.T(), s.service.activeExpedition.JumpHistory, 1)
@@ -145,7 +213,7 @@ func (s *ExpeditionServiceTestSuite) TestCorrectlyRecordJumpToStartSystem() {
 	// Simulate journal updates
 	jumpTime := time.Date(2025, 12, 20, 10, 0, 0, 0, time.UTC)
 	simulateJump(s.T(), s.tmpDir, Jump{name: "Sol", id: 1, distance: &s.distance, fuelUsed: &s.fuelUsed, fuelLevel: &s.fuelLevel}, jumpTime)
-	time.Sleep(10 * time.Millisecond)
+	s.waitForJumps(1)
 
 	// Assert updates
 	assert.Len(s.T(), s.service.activeExpedition.JumpHistory, 1)
@@ -168,7 +236,7 @@ func (s *ExpeditionServiceTestSuite) TestCorrectlyRecordDetour() {
 	// Jump to a system that's not in the route at all
 	jumpTime := time.Date(2025, 12, 20, 10, 0, 0, 0, time.UTC)
 	simulateJump(s.T(), s.tmpDir, Jump{name: "Betelgeuse", id: 999, distance: &s.distance, fuelUsed: &s.fuelUsed, fuelLevel: &s.fuelLevel}, jumpTime)
-	time.Sleep(10 * time.Millisecond)
+	s.waitForJumps(1)
 
 	// Assert detour was recorded
 	assert.Len(s.T(), s.service.activeExpedition.JumpHistory, 1)
@@ -185,13 +253,13 @@ func (s *ExpeditionServiceTestSuite) TestCorrectlyRecordDetourThenExpected() {
 	// First jump: detour to system not in route
 	jumpTime1 := time.Date(2025, 12, 20, 10, 0, 0, 0, time.UTC)
 	simulateJump(s.T(), s.tmpDir, Jump{name: "Betelgeuse", id: 999, distance: &s.distance, fuelUsed: &s.fuelUsed, fuelLevel: &s.fuelLevel}, jumpTime1)
-	time.Sleep(10 * time.Millisecond)
+	s.waitForJumps(1)
 
 	// Second jump: expected system (Alpha Centauri)
 	s.bakedIndex = 1
 	jumpTime2 := time.Date(2025, 12, 20, 10, 5, 0, 0, time.UTC)
 	simulateJump(s.T(), s.tmpDir, Jump{name: "Alpha Centauri", id: 2, distance: &s.distance, fuelUsed: &s.fuelUsed, fuelLevel: &s.fuelLevel}, jumpTime2)
-	time.Sleep(10 * time.Millisecond)
+	s.waitForJumps(2)
 
 	// Assert both jumps recorded correctly
 	assert.Len(s.T(), s.service.activeExpedition.JumpHistory, 2)
@@ -221,7 +289,7 @@ func (s *ExpeditionServiceTestSuite) TestCorrectlyRecordDetourToOnRouteButNotExp
 	s.bakedIndex = 2
 	jumpTime := time.Date(2025, 12, 20, 10, 0, 0, 0, time.UTC)
 	simulateJump(s.T(), s.tmpDir, Jump{name: "Bernard's Star", id: 3, distance: &s.distance, fuelUsed: &s.fuelUsed, fuelLevel: &s.fuelLevel}, jumpTime)
-	time.Sleep(10 * time.Millisecond)
+	s.waitForJumps(1)
 
 	// Assert jump recorded as on-route but not expected
 	assert.Len(s.T(), s.service.activeExpedition.JumpHistory, 1)
@@ -272,7 +340,7 @@ func (s *ExpeditionServiceTestSuite) TestRejectDuplicateTimestamp() {
 	jumpTime := time.Date(2025, 12, 20, 10, 0, 0, 0, time.UTC)
 	s.bakedIndex = 1
 	simulateJump(s.T(), s.tmpDir, Jump{name: "Alpha Centauri", id: 2, distance: &s.distance, fuelUsed: &s.fuelUsed, fuelLevel: &s.fuelLevel}, jumpTime)
-	time.Sleep(10 * time.Millisecond)
+	s.waitForJumps(1)
 
 	// Verify first jump recorded
 	assert.Len(s.T(), s.service.activeExpedition.JumpHistory, 1)
@@ -280,7 +348,7 @@ func (s *ExpeditionServiceTestSuite) TestRejectDuplicateTimestamp() {
 
 	// Second jump: exact same timestamp (should be rejected)
 	simulateJump(s.T(), s.tmpDir, Jump{name: "Bernard's Star", id: 3, distance: &s.distance, fuelUsed: &s.fuelUsed, fuelLevel: &s.fuelLevel}, jumpTime)
-	time.Sleep(10 * time.Millisecond)
+	s.waitForTrace("handleJump: system=Bernard's Star")
 
 	// Verify second jump was NOT added (still only 1 jump in history)
 	assert.Len(s.T(), s.service.activeExpedition.JumpHistory, 1)
@@ -290,7 +358,7 @@ func (s *ExpeditionServiceTestSuite) TestRejectDuplicateTimestamp() {
 	jumpTime2 := jumpTime.Add(1 * time.Second)
 	s.bakedIndex = 2
 	simulateJump(s.T(), s.tmpDir, Jump{name: "Bernard's Star", id: 3, distance: &s.distance, fuelUsed: &s.fuelUsed, fuelLevel: &s.fuelLevel}, jumpTime2)
-	time.Sleep(10 * time.Millisecond)
+	s.waitForJumps(2)
 
 	// Verify third jump was added
 	assert.Len(s.T(), s.service.activeExpedition.JumpHistory, 2)
@@ -318,7 +386,7 @@ func (s *ExpeditionServiceTestSuite) TestAutoCompleteWhenReachingLastJump() {
 		}
 
 		simulateJump(s.T(), s.tmpDir, Jump{name: jump.name, id: jump.id, distance: &s.distance, fuelUsed: &s.fuelUsed, fuelLevel: &s.fuelLevel}, jumpTime)
-		time.Sleep(10 * time.Millisecond)
+		s.waitForJumps(i + 1)
 	}
 
 	// Verify all jumps recorded
@@ -342,6 +410,645 @@ func (s *ExpeditionServiceTestSuite) TestAutoCompleteWhenReachingLastJump() {
 	assert.Equal(s.T(), models.StatusCompleted, expeditionInIndex.Status)
 }
 
+func (s *ExpeditionServiceTestSuite) TestRecordsExplorationOnMatchingJump() {
+	jumpTime := time.Date(2025, 12, 20, 10, 0, 0, 0, time.UTC)
+	simulateJump(s.T(), s.tmpDir, Jump{name: "Alpha Centauri", id: 2, distance: &s.distance, fuelUsed: &s.fuelUsed, fuelLevel: &s.fuelLevel}, jumpTime)
+	s.waitForJumps(1)
+
+	ts := jumpTime.Add(time.Minute).Format(time.RFC3339)
+	simulateEvent(s.T(), s.tmpDir, `{"timestamp":"`+ts+`","event":"FSSDiscoveryScan","Progress":0.5,"BodyCount":3,"NonBodyCount":1,"SystemName":"Alpha Centauri","SystemAddress":2}`)
+	simulateEvent(s.T(), s.tmpDir, `{"timestamp":"`+ts+`","event":"Scan","ScanType":"AutoScan","BodyName":"Alpha Centauri A","BodyID":0,"StarSystem":"Alpha Centauri","SystemAddress":2,"StarType":"G","WasDiscovered":true,"WasMapped":false}`)
+	simulateEvent(s.T(), s.tmpDir, `{"timestamp":"`+ts+`","event":"Scan","ScanType":"Detailed","BodyName":"Alpha Centauri A","BodyID":0,"StarSystem":"Alpha Centauri","SystemAddress":2,"StarType":"G","WasDiscovered":true,"WasMapped":false}`)
+	simulateEvent(s.T(), s.tmpDir, `{"timestamp":"`+ts+`","event":"FSSAllBodiesFound","SystemName":"Alpha Centauri","SystemAddress":2,"Count":3}`)
+	simulateEvent(s.T(), s.tmpDir, `{"timestamp":"`+ts+`","event":"SAASignalsFound","BodyName":"Alpha Centauri A 1","BodyID":3,"SystemAddress":2,"Signals":[{"Type":"$SAA_SignalType_Biological;","Type_Localised":"Biological","Count":2}]}`)
+	// Scans for systems we have no jump for are ignored
+	simulateEvent(s.T(), s.tmpDir, `{"timestamp":"`+ts+`","event":"FSSDiscoveryScan","Progress":1,"BodyCount":9,"NonBodyCount":0,"SystemName":"Sol","SystemAddress":1}`)
+	s.waitFor(func() bool {
+		exploration := s.service.activeExpedition.JumpHistory[0].Exploration
+		return exploration != nil && exploration.Honked && exploration.AllBodiesFound &&
+			len(exploration.ScannedBodies) == 1 && len(exploration.MappedBodies) == 1
+	}, "the exploration of Alpha Centauri")
+
+	s.Require().Len(s.service.activeExpedition.JumpHistory, 1)
+	exploration := s.service.activeExpedition.JumpHistory[0].Exploration
+	s.Require().NotNil(exploration)
+	s.True(exploration.Honked)
+	s.True(exploration.AllBodiesFound)
+	s.Equal(3, exploration.BodyCount)
+	s.Equal(1, exploration.NonBodyCount)
+	s.Require().Len(exploration.ScannedBodies, 1)
+	s.Equal("G", exploration.ScannedBodies[0].Type)
+	s.Require().Len(exploration.MappedBodies, 1)
+	s.Equal([]models.BodySignal{{Type: "Biological", Count: 2}}, exploration.MappedBodies[0].Signals)
+}
+
+func (s *ExpeditionServiceTestSuite) TestRecordsFuelScoopingPerJump() {
+	jumpTime := time.Date(2025, 12, 20, 10, 0, 0, 0, time.UTC)
+	simulateJump(s.T(), s.tmpDir, Jump{name: "Alpha Centauri", id: 2, distance: &s.distance, fuelUsed: &s.fuelUsed, fuelLevel: &s.fuelLevel}, jumpTime)
+	s.waitForJumps(1)
+
+	s.service.mu.Lock()
+	s.service.handleRefueling(true)
+	s.service.mu.Unlock()
+	time.Sleep(20 * time.Millisecond)
+	s.service.mu.Lock()
+	s.service.handleRefueling(false)
+	s.service.mu.Unlock()
+
+	ts := jumpTime.Add(time.Minute).Format(time.RFC3339)
+	simulateEvent(s.T(), s.tmpDir, `{"timestamp":"`+ts+`","event":"FuelScoop","Scooped":5.5,"Total":19.5}`)
+	simulateEvent(s.T(), s.tmpDir, `{"timestamp":"`+ts+`","event":"FuelScoop","Scooped":12.5,"Total":32}`)
+	s.waitFor(func() bool {
+		record := s.service.activeExpedition.JumpHistory[0].FuelScoop
+		return record != nil && record.Scooped == 18
+	}, "both scoops recorded")
+
+	s.Require().Len(s.service.activeExpedition.JumpHistory, 1)
+	record := s.service.activeExpedition.JumpHistory[0].FuelScoop
+	s.Require().NotNil(record)
+	s.Equal(18.0, record.Scooped)
+	s.Equal(14.0, record.StartFuel)
+	s.Equal(32.0, record.EndFuel)
+	s.GreaterOrEqual(record.Duration, 0.02)
+
+	scooped, duration := scoopTotals(s.service.activeExpedition.JumpHistory)
+	s.Equal(18.0, scooped)
+	s.Equal(record.Duration, duration)
+}
+
+func (s *ExpeditionServiceTestSuite) TestActiveExpeditionIsKeptPerCommander() {
+	s.service.activeExpedition.CommanderFID = "F1"
+	s.Require().NoError(models.SaveExpedition(s.service.activeExpedition))
+
+	jumpTime := time.Date(2025, 12, 20, 10, 0, 0, 0, time.UTC)
+	simulateEvent(s.T(), s.tmpDir, `{"timestamp":"`+jumpTime.Format(time.RFC3339)+`","event":"Commander","FID":"F2","Name":"Alt"}`)
+	s.waitFor(func() bool { return s.service.activeExpedition == nil }, "the expedition parked")
+	s.Nil(s.service.activeExpedition, "parked while the other commander plays")
+	s.Nil(s.service.Index.ActiveExpeditionID)
+	s.Equal(map[string]string{"F1": "active"}, s.service.Index.ParkedExpeditions)
+	s.Error(s.service.DeleteExpedition("active"), "still active for F1")
+
+	simulateJump(s.T(), s.tmpDir, Jump{name: "Alpha Centauri", id: 2, distance: &s.distance, fuelUsed: &s.fuelUsed, fuelLevel: &s.fuelLevel}, jumpTime.Add(time.Minute))
+	s.waitForTrace("handleJump: system=Alpha Centauri")
+
+	simulateEvent(s.T(), s.tmpDir, `{"timestamp":"`+jumpTime.Add(2*time.Minute).Format(time.RFC3339)+`","event":"Commander","FID":"F1","Name":"Main"}`)
+	s.waitFor(func() bool { return s.service.activeExpedition != nil }, "the expedition back")
+	s.Require().NotNil(s.service.activeExpedition)
+	s.Equal("active", s.service.activeExpedition.ID)
+	s.Empty(s.service.activeExpedition.JumpHistory, "the jump of F2 was not recorded")
+	s.Empty(s.service.Index.ParkedExpeditions)
+
+	simulateJump(s.T(), s.tmpDir, Jump{name: "Alpha Centauri", id: 2, distance: &s.distance, fuelUsed: &s.fuelUsed, fuelLevel: &s.fuelLevel}, jumpTime.Add(3*time.Minute))
+	s.waitForJumps(1)
+	s.Len(s.service.activeExpedition.JumpHistory, 1)
+
+	index, err := models.LoadIndex()
+	s.Require().NoError(err)
+	s.Require().NotNil(index.ActiveExpeditionID)
+	s.Equal("active", *index.ActiveExpeditionID)
+}
+
+func (s *ExpeditionServiceTestSuite) TestSkipsPassengerJumps() {
+	ts := time.Date(2025, 12, 20, 10, 0, 0, 0, time.UTC).Format(time.RFC3339)
+	simulateEvent(s.T(), s.tmpDir, `{"timestamp":"`+ts+`","event":"FSDJump","Taxi":true,"Multicrew":false,"StarSystem":"Alpha Centauri","SystemAddress":2,"StarPos":[0,0,0],"JumpDist":4.4}`)
+	s.waitForTrace("handleJump: system=Alpha Centauri")
+
+	s.Empty(s.service.activeExpedition.JumpHistory)
+	s.Equal(-1, s.service.activeExpedition.CurrentBakedIndex)
+}
+
+func (s *ExpeditionServiceTestSuite) TestRecordsPassengerJumpsOffExpedition() {
+	s.service.SetPassengerJumpPolicy(models.PassengerJumpRecord)
+
+	ts := time.Date(2025, 12, 20, 10, 0, 0, 0, time.UTC).Format(time.RFC3339)
+	simulateEvent(s.T(), s.tmpDir, `{"timestamp":"`+ts+`","event":"FSDJump","Taxi":false,"Multicrew":true,"StarSystem":"Alpha Centauri","SystemAddress":2,"StarPos":[0,0,0],"JumpDist":4.4}`)
+	s.waitForJumps(1)
+
+	s.Require().Len(s.service.activeExpedition.JumpHistory, 1)
+	entry := s.service.activeExpedition.JumpHistory[0]
+	s.True(entry.OffExpedition)
+	s.False(entry.Expected)
+	s.Nil(entry.BakedIndex)
+	s.Equal(-1, s.service.activeExpedition.CurrentBakedIndex)
+}
+
+func (s *ExpeditionServiceTestSuite) TestRebuildHistoryFromJournals() {
+	// Nothing should be handled live, the journals are only read by the rebuild
+	s.service.Stop()
+
+	expedition := s.service.activeExpedition
+	expedition.StartedOn = time.Date(2025, 12, 20, 9, 0, 0, 0, time.UTC)
+	expedition.JumpHistory = []models.JumpHistoryEntry{
+		{Timestamp: time.Date(2025, 12, 20, 10, 5, 0, 0, time.UTC), SystemName: "Bogus", SystemID: 99},
+	}
+
+	simulateEvent(s.T(), s.tmpDir, `{"timestamp":"2025-12-20T08:00:00Z","event":"FSDJump","StarSystem":"Sol","SystemAddress":1,"StarPos":[0,0,0],"JumpDist":5}`)
+	simulateEvent(s.T(), s.tmpDir, `{"timestamp":"2025-12-20T10:00:00Z","event":"FSDJump","StarSystem":"Alpha Centauri","SystemAddress":2,"StarPos":[0,0,0],"JumpDist":4.4}`)
+	// Bernard's Star is missing from the journals
+	simulateEvent(s.T(), s.tmpDir, `{"timestamp":"2025-12-20T10:10:00Z","event":"FSDJump","StarSystem":"Luhman 16","SystemAddress":4,"StarPos":[100,0,0],"JumpDist":10}`)
+
+	rebuild, err := s.service.RebuildHistory("active")
+	s.Require().NoError(err)
+
+	s.Require().Len(rebuild.JumpHistory, 3)
+	s.Equal("Alpha Centauri", rebuild.JumpHistory[0].SystemName)
+	s.False(rebuild.JumpHistory[0].Synthetic)
+	s.Equal("Bernard's Star", rebuild.JumpHistory[1].SystemName)
+	s.True(rebuild.JumpHistory[1].Synthetic)
+	s.Equal(2, *rebuild.JumpHistory[1].BakedIndex)
+	s.Equal("Luhman 16", rebuild.JumpHistory[2].SystemName)
+	s.True(rebuild.JumpHistory[2].Expected)
+	s.Equal(3, rebuild.CurrentBakedIndex)
+	s.Equal(1, rebuild.FilledGaps)
+
+	kinds := slice.Map(rebuild.Diff, func(d HistoryDiffEntry) HistoryDiffKind { return d.Kind })
+	s.Equal([]HistoryDiffKind{HistoryDiffAdded, HistoryDiffAdded, HistoryDiffRemoved, HistoryDiffAdded}, kinds)
+
+	// Nothing is written before the rebuild is applied
+	s.Equal("Bogus", expedition.JumpHistory[0].SystemName)
+
+	s.Require().NoError(s.service.ApplyHistoryRebuild("active"))
+	s.Len(expedition.JumpHistory, 3)
+	s.Equal(models.StatusCompleted, expedition.Status)
+	s.Nil(s.service.activeExpedition)
+}
+
+func (s *ExpeditionServiceTestSuite) TestRecordsDeathAndRewinds() {
+	jumpTime := time.Date(2025, 12, 20, 10, 0, 0, 0, time.UTC)
+	simulateJump(s.T(), s.tmpDir, Jump{name: "Alpha Centauri", id: 2, distance: &s.distance, fuelUsed: &s.fuelUsed, fuelLevel: &s.fuelLevel}, jumpTime)
+	s.waitForJumps(1)
+	simulateEvent(s.T(), s.tmpDir, `{"timestamp":"2025-12-20T10:05:00Z","event":"Died","KillerName":"Thargoid"}`)
+	simulateEvent(s.T(), s.tmpDir, `{"timestamp":"2025-12-20T10:06:00Z","event":"Resurrect","Option":"rebuy","Cost":1000,"Bankrupt":false}`)
+	simulateEvent(s.T(), s.tmpDir, `{"timestamp":"2025-12-20T10:07:00Z","event":"Location","Docked":true,"StarSystem":"Shinrarta Dezhra","SystemAddress":99,"StarPos":[0,0,0]}`)
+	s.waitForJumps(2)
+
+	s.Require().Len(s.service.activeExpedition.JumpHistory, 2)
+	death := s.service.activeExpedition.JumpHistory[1]
+	s.Require().NotNil(death.Death)
+	s.Equal("Shinrarta Dezhra", death.SystemName)
+	s.Equal("Alpha Centauri", death.Death.SystemName)
+	s.Equal("Thargoid", death.Death.KilledBy)
+	s.Equal("rebuy", death.Death.Resurrect)
+	s.True(death.OffExpedition)
+	s.Equal(1, s.service.activeExpedition.CurrentBakedIndex)
+
+	from, to, err := s.service.RewindTarget()
+	s.Require().NoError(err)
+	s.Equal("Shinrarta Dezhra", from.SystemName)
+	s.Equal("Alpha Centauri", to.SystemName)
+
+	prevBakedRouteID := *s.service.activeExpedition.BakedRouteID
+	s.Require().NoError(s.service.SpliceRewindRoute(&models.Route{
+		ID: "rewind",
+		Jumps: []models.RouteJump{
+			{SystemName: "Shinrarta Dezhra", SystemID: 99},
+			{SystemName: "Midway", SystemID: 50, Distance: 20},
+			{SystemName: "Alpha Centauri", SystemID: 2, Distance: 20},
+		},
+	}))
+	s.Len(s.service.bakedRoute.Jumps, 7)
+	s.Equal(2, s.service.activeExpedition.CurrentBakedIndex)
+	s.Equal(2, *s.service.activeExpedition.JumpHistory[1].BakedIndex)
+	s.NotContains(s.service.activeExpedition.Routes, "rewind")
+	_, err = models.LoadRoute(prevBakedRouteID)
+	s.Error(err, "the replaced baked route is deleted")
+
+	simulateJump(s.T(), s.tmpDir, Jump{name: "Midway", id: 50, distance: &s.distance, fuelUsed: &s.fuelUsed, fuelLevel: &s.fuelLevel}, jumpTime.Add(10*time.Minute))
+	s.waitForJumps(3)
+	simulateJump(s.T(), s.tmpDir, Jump{name: "Alpha Centauri", id: 2, distance: &s.distance, fuelUsed: &s.fuelUsed, fuelLevel: &s.fuelLevel}, jumpTime.Add(11*time.Minute))
+	s.waitForJumps(4)
+	simulateJump(s.T(), s.tmpDir, Jump{name: "Bernard's Star", id: 3, distance: &s.distance, fuelUsed: &s.fuelUsed, fuelLevel: &s.fuelLevel}, jumpTime.Add(12*time.Minute))
+	s.waitForJumps(5)
+
+	history := s.service.activeExpedition.JumpHistory
+	s.Require().Len(history, 5)
+	for _, entry := range history[2:] {
+		s.True(entry.Expected, entry.SystemName)
+	}
+	s.Equal(5, s.service.activeExpedition.CurrentBakedIndex)
+}
+
+func (s *ExpeditionServiceTestSuite) TestRewindKeepsCompletedLaps() {
+	jumpTime := time.Date(2025, 12, 20, 10, 0, 0, 0, time.UTC)
+	s.loopBackTo(1, jumpTime)
+
+	jumps := 0
+	jump := func(name string, id int64, offset time.Duration) {
+		simulateJump(s.T(), s.tmpDir, Jump{name: name, id: id, distance: &s.distance, fuelUsed: &s.fuelUsed, fuelLevel: &s.fuelLevel}, jumpTime.Add(offset))
+		jumps++
+		s.waitForJumps(jumps)
+	}
+	jump("Alpha Centauri", 2, 0)
+	jump("Bernard's Star", 3, time.Minute)
+	jump("Luhman 16", 4, 2*time.Minute)
+	jump("Bernard's Star", 3, 3*time.Minute)
+	s.Require().Equal(1, s.service.activeExpedition.CompletedLaps())
+
+	simulateEvent(s.T(), s.tmpDir, `{"timestamp":"2025-12-20T10:05:00Z","event":"Died","KillerName":"Thargoid"}`)
+	simulateEvent(s.T(), s.tmpDir, `{"timestamp":"2025-12-20T10:06:00Z","event":"Resurrect","Option":"rebuy","Cost":1000,"Bankrupt":false}`)
+	simulateEvent(s.T(), s.tmpDir, `{"timestamp":"2025-12-20T10:07:00Z","event":"Location","Docked":true,"StarSystem":"Shinrarta Dezhra","SystemAddress":99,"StarPos":[0,0,0]}`)
+	s.waitForJumps(jumps + 1)
+
+	s.service.mu.Lock()
+	s.service.activeExpedition.Stats = &models.ExpeditionStats{}
+	s.service.mu.Unlock()
+	s.Require().NoError(s.service.SpliceRewindRoute(&models.Route{
+		ID: "rewind",
+		Jumps: []models.RouteJump{
+			{SystemName: "Shinrarta Dezhra", SystemID: 99},
+			{SystemName: "Midway", SystemID: 50, Distance: 20},
+			{SystemName: "Bernard's Star", SystemID: 3, Distance: 20},
+		},
+	}))
+
+	expedition := s.service.activeExpedition
+	s.Require().Len(s.service.bakedRoute.Jumps, 7)
+	s.Equal(6, *expedition.JumpHistory[2].BakedIndex, "the lap end moved with the last system")
+	s.Equal(1, expedition.CompletedLaps())
+	s.Require().Len(expedition.Laps, 2)
+	s.True(expedition.Laps[0].End.Equal(jumpTime.Add(2 * time.Minute)))
+	s.Require().NotNil(expedition.Stats)
+	s.Equal(4, expedition.Stats.Jumps)
+}
+
+func (s *ExpeditionServiceTestSuite) TestBoostAlertAndRecordedBoost() {
+	neutron := models.FSDBoostNeutron
+	s.service.bakedRoute.Jumps[1].FSDBoost = &neutron
+
+	alertChan := s.service.BoostAlert.Subscribe()
+	defer s.service.BoostAlert.Unsubscribe(alertChan)
+	jumpTime := time.Date(2025, 12, 20, 10, 0, 0, 0, time.UTC)
+	simulateJump(s.T(), s.tmpDir, Jump{name: "Alpha Centauri", id: 2, distance: &s.distance, fuelUsed: &s.fuelUsed, fuelLevel: &s.fuelLevel}, jumpTime)
+
+	alert := receiveWithin(s.T(), alertChan, "boost alert")
+	s.False(alert.Ready)
+	s.Equal(models.FSDBoostNeutron, alert.Required)
+	s.Contains(alert.Message, "Bernard's Star")
+
+	simulateEvent(s.T(), s.tmpDir, `{"timestamp":"2025-12-20T10:01:00Z","event":"JetConeBoost","BoostValue":4.0}`)
+
+	alert = receiveWithin(s.T(), alertChan, "boost alert")
+	s.True(alert.Ready)
+	s.Equal(models.FSDBoostNeutron, alert.Available)
+
+	simulateJump(s.T(), s.tmpDir, Jump{name: "Bernard's Star", id: 3, distance: &s.distance, fuelUsed: &s.fuelUsed, fuelLevel: &s.fuelLevel}, jumpTime.Add(2*time.Minute))
+
+	alert = receiveWithin(s.T(), alertChan, "boost alert")
+	s.True(alert.Ready, "the jump to Luhman 16 needs no boost")
+	s.Equal(models.FSDBoostNone, alert.Available)
+
+	history := s.service.activeExpedition.JumpHistory
+	s.Require().Len(history, 2)
+	s.Nil(history[0].FSDBoost)
+	s.Require().NotNil(history[1].FSDBoost)
+	s.Equal(models.FSDBoostNeutron, *history[1].FSDBoost)
+}
+
+func (s *ExpeditionServiceTestSuite) TestWhiteDwarfBoost() {
+	standard := models.FSDBoostInjectionStandard
+	s.service.bakedRoute.Jumps[1].FSDBoost = &standard
+
+	alertChan := s.service.BoostAlert.Subscribe()
+	defer s.service.BoostAlert.Unsubscribe(alertChan)
+	jumpTime := time.Date(2025, 12, 20, 10, 0, 0, 0, time.UTC)
+	simulateJump(s.T(), s.tmpDir, Jump{name: "Alpha Centauri", id: 2, distance: &s.distance, fuelUsed: &s.fuelUsed, fuelLevel: &s.fuelLevel}, jumpTime)
+
+	alert := receiveWithin(s.T(), alertChan, "boost alert")
+	s.False(alert.Ready)
+
+	simulateEvent(s.T(), s.tmpDir, `{"timestamp":"2025-12-20T10:01:00Z","event":"JetConeBoost","BoostValue":1.5}`)
+
+	alert = receiveWithin(s.T(), alertChan, "boost alert")
+	s.True(alert.Ready, "a white dwarf supercharge is as good as a standard injection")
+	s.Equal(models.FSDBoostWhiteDwarf, alert.Available)
+
+	simulateJump(s.T(), s.tmpDir, Jump{name: "Bernard's Star", id: 3, distance: &s.distance, fuelUsed: &s.fuelUsed, fuelLevel: &s.fuelLevel}, jumpTime.Add(2*time.Minute))
+	receiveWithin(s.T(), alertChan, "boost alert")
+
+	history := s.service.activeExpedition.JumpHistory
+	s.Require().Len(history, 2)
+	s.Require().NotNil(history[1].FSDBoost)
+	s.Equal(models.FSDBoostWhiteDwarf, *history[1].FSDBoost)
+}
+
+func (s *ExpeditionServiceTestSuite) TestDamageAlertBeforeNeutronJump() {
+	neutron := models.FSDBoostNeutron
+	s.service.bakedRoute.Jumps[2].FSDBoost = &neutron
+	s.service.SetFSDHealthThreshold(0.8)
+
+	alertChan := s.service.DamageAlert.Subscribe()
+	defer s.service.DamageAlert.Unsubscribe(alertChan)
+	simulateEvent(s.T(), s.tmpDir, `{"timestamp":"2025-12-20T09:59:00Z","event":"Loadout","Ship":"anaconda","ShipID":1,"HullHealth":0.9,"Modules":[{"Slot":"FrameShiftDrive","Item":"int_hyperdrive_size5_class5","On":true,"Priority":0,"Health":0.75}]}`)
+	s.waitFor(func() bool { return s.service.healthSnapshot() != nil }, "the loadout health")
+
+	jumpTime := time.Date(2025, 12, 20, 10, 0, 0, 0, time.UTC)
+	simulateJump(s.T(), s.tmpDir, Jump{name: "Alpha Centauri", id: 2, distance: &s.distance, fuelUsed: &s.fuelUsed, fuelLevel: &s.fuelLevel}, jumpTime)
+
+	alert := receiveWithin(s.T(), alertChan, "damage alert")
+	s.False(alert.Ok)
+	s.Equal(0.75, alert.FSDHealth)
+	s.Require().NotNil(alert.NeutronJumpsAway)
+	s.Equal(1, *alert.NeutronJumpsAway)
+	s.Contains(alert.Message, "Bernard's Star")
+
+	history := s.service.activeExpedition.JumpHistory
+	s.Require().Len(history, 1)
+	s.Require().NotNil(history[0].Health)
+	s.Equal(0.9, *history[0].Health.Hull)
+	s.Equal(0.75, *history[0].Health.FSD)
+
+	simulateEvent(s.T(), s.tmpDir, `{"timestamp":"2025-12-20T10:01:00Z","event":"HullDamage","Health":0.6,"PlayerPilot":true,"Fighter":false}`)
+
+	alert = receiveWithin(s.T(), alertChan, "damage alert")
+	s.False(alert.Ok)
+	s.Require().NotNil(alert.HullHealth)
+	s.Equal(0.6, *alert.HullHealth)
+	s.Equal(0.6, *s.service.activeExpedition.JumpHistory[0].Health.Hull)
+
+	simulateEvent(s.T(), s.tmpDir, `{"timestamp":"2025-12-20T10:02:00Z","event":"AfmuRepairs","Module":"$int_hyperdrive_size5_class5_name;","Module_Localised":"FSD","FullyRepaired":false,"Health":0.9}`)
+
+	alert = receiveWithin(s.T(), alertChan, "damage alert")
+	s.True(alert.Ok)
+	s.Equal(0.9, alert.FSDHealth)
+	s.Empty(alert.Message)
+
+	simulateEvent(s.T(), s.tmpDir, `{"timestamp":"2025-12-20T10:03:00Z","event":"Repair","Items":["Wear","Hull"],"Cost":100}`)
+
+	alert = receiveWithin(s.T(), alertChan, "damage alert")
+	s.Equal(1.0, *alert.HullHealth)
+	s.Equal(0.9, alert.FSDHealth)
+}
+
+func (s *ExpeditionServiceTestSuite) TestCarrierJumpsCountWhenOptedIn() {
+	s.service.bakedRoute.Jumps[1].Distance = 4.4
+
+	carrierJump := func(ts, name string, id int64) string {
+		return fmt.Sprintf(`{"timestamp":"%s","event":"CarrierJump","Docked":true,"StationName":"X9Z-1AB","StationType":"FleetCarrier","MarketID":3700000000,"StarSystem":"%s","SystemAddress":%d,"StarPos":[0,0,0],"Body":"%s","BodyID":0,"BodyType":"Star"}`, ts, name, id, name)
+	}
+
+	simulateEvent(s.T(), s.tmpDir, carrierJump("2025-12-20T10:00:00Z", "Alpha Centauri", 2))
+	s.waitForTrace("carrier jump to Alpha Centauri not counted")
+	s.Empty(s.service.activeExpedition.JumpHistory, "carrier jumps are not counted by default")
+
+	s.Require().NoError(s.service.SetCountCarrierJumps("active", true))
+	s.True(s.service.activeExpedition.CountCarrierJumps)
+
+	simulateEvent(s.T(), s.tmpDir, carrierJump("2025-12-20T11:00:00Z", "Alpha Centauri", 2))
+	s.waitForJumps(1)
+
+	s.Require().Len(s.service.activeExpedition.JumpHistory, 1)
+	entry := s.service.activeExpedition.JumpHistory[0]
+	s.True(entry.Carrier)
+	s.True(entry.Expected)
+	s.Require().NotNil(entry.BakedIndex)
+	s.Equal(1, *entry.BakedIndex)
+	s.Equal(4.4, entry.Distance)
+	s.Zero(entry.FuelUsed)
+	s.Zero(entry.FuelLevel)
+	s.Equal(1, s.service.activeExpedition.CurrentBakedIndex)
+
+	saved, err := models.LoadExpedition("active")
+	s.Require().NoError(err)
+	s.True(saved.CountCarrierJumps)
+}
+
+func (s *ExpeditionServiceTestSuite) TestPauseAndResume() {
+	jumpTime := time.Date(2025, 12, 20, 10, 0, 0, 0, time.UTC)
+	simulateJump(s.T(), s.tmpDir, Jump{name: "Alpha Centauri", id: 2, distance: &s.distance, fuelUsed: &s.fuelUsed, fuelLevel: &s.fuelLevel}, jumpTime)
+	s.waitForJumps(1)
+	s.Require().Len(s.service.activeExpedition.JumpHistory, 1)
+
+	s.Require().NoError(s.service.PauseExpedition())
+	s.Nil(s.service.activeExpedition)
+	s.Nil(s.service.Index.ActiveExpeditionID)
+
+	paused, err := models.LoadExpedition("active")
+	s.Require().NoError(err)
+	s.Equal(models.StatusPaused, paused.Status)
+	s.Require().Len(paused.Pauses, 1)
+	s.True(paused.Pauses[0].To.IsZero())
+
+	// Jumps during the break are not recorded
+	simulateJump(s.T(), s.tmpDir, Jump{name: "Bernard's Star", id: 3, distance: &s.distance, fuelUsed: &s.fuelUsed, fuelLevel: &s.fuelLevel}, jumpTime.Add(time.Hour))
+	s.waitForTrace("handleJump: system=Bernard's Star")
+
+	s.Error(s.service.ResumeExpedition("unknown", nil, ""))
+
+	currentSystem := int64(3)
+	s.Require().NoError(s.service.ResumeExpedition("active", &currentSystem, ""))
+	s.Require().NotNil(s.service.activeExpedition)
+	s.Equal(models.StatusActive, s.service.activeExpedition.Status)
+	s.Equal(2, s.service.activeExpedition.CurrentBakedIndex)
+	s.False(s.service.activeExpedition.Pauses[0].To.IsZero())
+
+	history := s.service.activeExpedition.JumpHistory
+	s.Require().Len(history, 2)
+	s.True(history[1].Resumed)
+	s.Equal("Bernard's Star", history[1].SystemName)
+	s.Require().NotNil(history[1].BakedIndex)
+	s.Equal(2, *history[1].BakedIndex)
+
+	s.Error(s.service.ResumeExpedition("active", &currentSystem, ""), "already active")
+}
+
+func (s *ExpeditionServiceTestSuite) TestResumeChecksCommander() {
+	s.service.activeExpedition.CommanderFID = "F1"
+	s.Require().NoError(models.SaveExpedition(s.service.activeExpedition))
+	s.Require().NoError(s.service.PauseExpedition())
+
+	s.Error(s.service.ResumeExpedition("active", nil, "F2"), "belongs to F1")
+
+	s.service.Index.ParkedExpeditions = map[string]string{"F1": "other"}
+	s.Error(s.service.ResumeExpedition("active", nil, "F1"), "F1 has another expedition parked")
+
+	s.service.Index.ParkedExpeditions = map[string]string{"F1": "active"}
+	s.Require().NoError(s.service.ResumeExpedition("active", nil, "F1"))
+	s.Require().NotNil(s.service.activeExpedition)
+	s.Empty(s.service.Index.ParkedExpeditions)
+
+	index, err := models.LoadIndex()
+	s.Require().NoError(err)
+	s.Empty(index.ParkedExpeditions)
+}
+
+func (s *ExpeditionServiceTestSuite) TestEndPausedExpedition() {
+	jumpTime := time.Date(2025, 12, 20, 10, 0, 0, 0, time.UTC)
+	simulateJump(s.T(), s.tmpDir, Jump{name: "Alpha Centauri", id: 2, distance: &s.distance, fuelUsed: &s.fuelUsed, fuelLevel: &s.fuelLevel}, jumpTime)
+	s.waitForJumps(1)
+
+	s.Error(s.service.EndPausedExpedition("active"), "not paused")
+	s.Require().NoError(s.service.PauseExpedition())
+	s.Require().NoError(s.service.EndPausedExpedition("active"))
+
+	ended, err := models.LoadExpedition("active")
+	s.Require().NoError(err)
+	s.Equal(models.StatusEnded, ended.Status)
+	s.False(ended.EndedOn.IsZero())
+	s.False(ended.Pauses[0].To.IsZero())
+	s.Require().NotNil(ended.Stats)
+	s.Equal(1, ended.Stats.Jumps)
+	s.Equal(models.StatusEnded, s.service.Index.Expeditions[0].Status)
+
+	s.Error(s.service.ResumeExpedition("active", nil, ""), "ended expeditions can't be resumed")
+}
+
+func (s *ExpeditionServiceTestSuite) TestRejoinLooksUpSystemsWithoutPosition() {
+	for i := range s.service.bakedRoute.Jumps {
+		s.service.bakedRoute.Jumps[i].Position = nil
+	}
+
+	plotted := make(chan [2]string, 1)
+	s.service.SetRejoinAfter(1)
+	s.service.SetRejoinPlotter(func(from, to string) (*models.Route, error) {
+		plotted <- [2]string{from, to}
+		return &models.Route{Jumps: []models.RouteJump{
+			{SystemName: from, SystemID: 10},
+			{SystemName: to, SystemID: 4},
+		}}, nil
+	})
+	s.service.SetSystemsAround(func(pos vec.Vec3, radius float64) ([]*GalaxySystem, error) {
+		return []*GalaxySystem{
+			{Id: 1, Name: "Sol", Position: vec.NewVec3(95, 0, 0)},
+			{Id: 3, Name: "Bernard's Star", Position: vec.NewVec3(90, 0, 0)},
+			{Id: 4, Name: "Luhman 16", Position: vec.NewVec3(5, 0, 0)},
+			{Id: 5, Name: "Not on the route", Position: vec.NewVec3(1, 0, 0)},
+		}, nil
+	})
+
+	jumpTime := time.Date(2025, 12, 20, 10, 0, 0, 0, time.UTC)
+	simulateJump(s.T(), s.tmpDir, Jump{name: "Detour One", id: 10, distance: &s.distance, fuelUsed: &s.fuelUsed, fuelLevel: &s.fuelLevel}, jumpTime)
+
+	s.Equal([2]string{"Detour One", "Luhman 16"}, receiveWithin(s.T(), plotted, "the rejoin route to be plotted"))
+}
+
+func (s *ExpeditionServiceTestSuite) TestRejoinRouteAfterOffRouteJumps() {
+	far := vec.NewVec3(1000, 0, 0)
+	near := vec.NewVec3(10, 0, 0)
+	s.service.bakedRoute.Jumps[1].Position = &far
+	s.service.bakedRoute.Jumps[2].Position = &near
+	s.service.bakedRoute.Jumps[3].Position = &far
+
+	plotted := make(chan [2]string, 1)
+	s.service.SetRejoinAfter(2)
+	s.service.SetRejoinPlotter(func(from, to string) (*models.Route, error) {
+		plotted <- [2]string{from, to}
+		return &models.Route{Jumps: []models.RouteJump{
+			{SystemName: from, SystemID: 11},
+			{SystemName: "Midway", SystemID: 12},
+			{SystemName: to, SystemID: 3},
+		}}, nil
+	})
+
+	rejoinChan := s.service.Rejoin.Subscribe()
+	defer s.service.Rejoin.Unsubscribe(rejoinChan)
+	jumpTime := time.Date(2025, 12, 20, 10, 0, 0, 0, time.UTC)
+	simulateJump(s.T(), s.tmpDir, Jump{name: "Detour One", id: 10, distance: &s.distance, fuelUsed: &s.fuelUsed, fuelLevel: &s.fuelLevel}, jumpTime)
+	s.waitForJumps(1)
+	s.Empty(plotted, "one jump off route is not enough")
+
+	simulateJump(s.T(), s.tmpDir, Jump{name: "Detour Two", id: 11, distance: &s.distance, fuelUsed: &s.fuelUsed, fuelLevel: &s.fuelLevel}, jumpTime.Add(time.Minute))
+
+	suggestion := receiveWithin(s.T(), rejoinChan, "rejoin suggestion")
+	s.Equal([2]string{"Detour Two", "Bernard's Star"}, <-plotted)
+	s.Equal(2, suggestion.TargetIndex)
+	s.Equal("Midway", suggestion.NextSystem)
+	s.Equal("Midway", *s.service.GetNextSystemName())
+
+	simulateJump(s.T(), s.tmpDir, Jump{name: "Midway", id: 12, distance: &s.distance, fuelUsed: &s.fuelUsed, fuelLevel: &s.fuelLevel}, jumpTime.Add(2*time.Minute))
+
+	suggestion = receiveWithin(s.T(), rejoinChan, "rejoin suggestion")
+	s.Equal("Bernard's Star", suggestion.NextSystem)
+
+	simulateJump(s.T(), s.tmpDir, Jump{name: "Bernard's Star", id: 3, distance: &s.distance, fuelUsed: &s.fuelUsed, fuelLevel: &s.fuelLevel}, jumpTime.Add(3*time.Minute))
+
+	suggestion = receiveWithin(s.T(), rejoinChan, "rejoin suggestion")
+	s.Nil(suggestion.Route)
+	s.Empty(suggestion.NextSystem)
+	s.Equal("Luhman 16", *s.service.GetNextSystemName())
+}
+
+func (s *ExpeditionServiceTestSuite) TestStatsCachedOnCompletion() {
+	jumpTime := time.Date(2025, 12, 20, 10, 0, 0, 0, time.UTC)
+	jumps := []struct {
+		name   string
+		id     int64
+		offset time.Duration
+	}{
+		{"Alpha Centauri", 2, 0},
+		{"Detour", 99, 10 * time.Minute},
+		// Only counts up to the AFK gap
+		{"Bernard's Star", 3, 25 * time.Minute},
+		// A new session after a break
+		{"Luhman 16", 4, 2 * time.Hour},
+	}
+	for i, jump := range jumps {
+		simulateJump(s.T(), s.tmpDir, Jump{name: jump.name, id: jump.id, distance: &s.distance, fuelUsed: &s.fuelUsed, fuelLevel: &s.fuelLevel}, jumpTime.Add(jump.offset))
+		s.waitForJumps(i + 1)
+	}
+
+	s.Require().Nil(s.service.activeExpedition, "the expedition is completed")
+
+	expedition, err := models.LoadExpedition("active")
+	s.Require().NoError(err)
+	s.Require().NotNil(expedition.Stats)
+
+	stats := expedition.Stats
+	s.Equal(4, stats.Jumps)
+	s.Equal(80.0, stats.TotalDistance)
+	s.Equal(60.0, stats.OnRouteDistance)
+	s.Equal(1, stats.Detours)
+	s.Equal(20.0, stats.DetourDistance)
+	s.Equal(20.0, stats.AverageJump)
+	s.Equal(8.0, stats.FuelUsed)
+	s.Equal(100.0, stats.Completion)
+	s.Require().Len(stats.Sessions, 2)
+	s.Equal(3, stats.Sessions[0].Jumps)
+	s.Equal(1200.0, stats.Sessions[0].Duration)
+	s.Equal(1, stats.Sessions[1].Jumps)
+	s.Equal(0.0, stats.Sessions[1].Duration, "the break before a session is not play time")
+	s.Equal(1200.0, stats.PlayTime)
+	s.Equal(12.0, stats.JumpsPerHour)
+
+	cached, err := s.service.ExpeditionStats("active", false)
+	s.Require().NoError(err)
+	s.True(stats.ComputedAt.Equal(cached.ComputedAt))
+
+	recomputed, err := s.service.ExpeditionStats("active", true)
+	s.Require().NoError(err)
+	s.True(recomputed.ComputedAt.After(stats.ComputedAt))
+	s.Equal(stats.Jumps, recomputed.Jumps)
+}
+
+func (s *ExpeditionServiceTestSuite) TestETAPublishedAfterJumps() {
+	neutron := models.FSDBoostNeutron
+	s.service.bakedRoute.Jumps[2].MustRefuel = true
+	s.service.bakedRoute.Jumps[2].FSDBoost = &neutron
+
+	etaChan := s.service.ETA.Subscribe()
+	defer s.service.ETA.Unsubscribe(etaChan)
+	jumpTime := time.Date(2025, 12, 20, 10, 0, 0, 0, time.UTC)
+	simulateJump(s.T(), s.tmpDir, Jump{name: "Alpha Centauri", id: 2, distance: &s.distance, fuelUsed: &s.fuelUsed, fuelLevel: &s.fuelLevel}, jumpTime)
+
+	prediction := receiveWithin(s.T(), etaChan, "ETA")
+	s.Equal(2, prediction.RemainingJumps)
+	s.Zero(prediction.Samples)
+	s.Zero(prediction.RemainingTime, "no cadence yet")
+
+	simulateJump(s.T(), s.tmpDir, Jump{name: "Bernard's Star", id: 3, distance: &s.distance, fuelUsed: &s.fuelUsed, fuelLevel: &s.fuelLevel}, jumpTime.Add(time.Minute))
+
+	prediction = receiveWithin(s.T(), etaChan, "ETA")
+	s.Equal(1, prediction.RemainingJumps)
+	s.Equal(1, prediction.Samples)
+	s.Equal(60.0, prediction.JumpInterval)
+	s.Equal(1, prediction.RefuelStops)
+	s.Equal(60.0, prediction.RefuelTime)
+	s.Equal(1, prediction.BoostStops)
+	s.Equal(45.0, prediction.BoostTime)
+	s.Equal(165.0, prediction.RemainingTime)
+	s.Equal(60.0, prediction.SessionLength)
+	s.Equal(3, prediction.RemainingSessions)
+}
+
 func TestExpeditionServiceTestSuite(t *testing.T) {
 	suite.Run(t, new(ExpeditionServiceTestSuite))
 }
@@ -426,6 +1133,30 @@ func buildRouteJson(t *testing.T, id string, jumps []Jump) string {
 }`
 }
 
+// newRoute returns a route through systems named after their ID, its own ID is
+// id with "-route" appended
+func newRoute(id string, systems ...int64) *models.Route {
+	route := &models.Route{Version: migrations.RouteMigrations.LatestVersion(), ID: id + "-route", Name: id, Jumps: []models.RouteJump{}}
+	for _, system := range systems {
+		route.Jumps = append(route.Jumps, models.RouteJump{SystemName: fmt.Sprintf("System %d", system), SystemID: system})
+	}
+	return route
+}
+
+// receiveWithin returns the next value on ch, failing the test when nothing
+// arrives within a second
+func receiveWithin[T any](t *testing.T, ch chan T, what string) T {
+	t.Helper()
+	select {
+	case value := <-ch:
+		return value
+	case <-time.After(time.Second):
+		t.Fatalf("Timeout waiting for %s", what)
+		var zero T
+		return zero
+	}
+}
+
 func simulateJump(t *testing.T, dir string, jump Jump, timestamp time.Time) {
 	t.Helper()
 
@@ -456,3 +1187,363 @@ func simulateJump(t *testing.T, dir string, jump Jump, timestamp time.Time) {
 		t.Fatalf("Failed to write to journal file: %v", err)
 	}
 }
+
+func simulateEvent(t *testing.T, dir string, event string) {
+	t.Helper()
+
+	journalFile := filepath.Join(dir, "Journal.2025-12-20T100000.01.log")
+
+	file, err := os.OpenFile(journalFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatalf("Failed to open journal file: %v", err)
+	}
+	defer file.Close()
+
+	if _, err := file.WriteString(event + "\n"); err != nil {
+		t.Fatalf("Failed to write to journal file: %v", err)
+	}
+}
+
+func (s *ExpeditionServiceTestSuite) TestExportAndImportBundle() {
+	id := s.createExpedition(
+		[]*models.Route{newRoute("first", 1, 2, 3), newRoute("second", 3, 4, 5)},
+		[2]models.RoutePosition{{RouteID: "first-route", JumpIndex: 2}, {RouteID: "second-route", JumpIndex: 0}},
+	)
+
+	data, err := s.service.ExportExpedition(id)
+	s.Require().NoError(err)
+	s.NotContains(string(data), "first-route", "route IDs are rewritten")
+
+	importedId, err := s.service.ImportExpedition(data)
+	s.Require().NoError(err)
+	s.NotEqual(id, importedId)
+	s.True(slices.ContainsFunc(s.service.Index.Expeditions, func(summary models.ExpeditionSummary) bool {
+		return summary.ID == importedId && summary.Status == models.StatusPlanned
+	}))
+
+	imported, err := models.LoadExpedition(importedId)
+	s.Require().NoError(err)
+	s.Equal("first", imported.Name)
+	s.Equal(models.StatusPlanned, imported.Status)
+	s.Require().Len(imported.Routes, 2)
+	s.NotContains(imported.Routes, "first-route")
+	s.Require().NotNil(imported.Start)
+	s.Equal(models.RoutePosition{RouteID: imported.Routes[0], JumpIndex: 0}, *imported.Start)
+	s.Require().Len(imported.Links, 1)
+	s.Equal(models.RoutePosition{RouteID: imported.Routes[0], JumpIndex: 2}, imported.Links[0].From)
+	s.Equal(models.RoutePosition{RouteID: imported.Routes[1], JumpIndex: 0}, imported.Links[0].To)
+
+	route, err := models.LoadRoute(imported.Routes[1])
+	s.Require().NoError(err)
+	s.Len(route.Jumps, 3)
+
+	// A link between different systems is rejected
+	broken := strings.Replace(string(data), `"jump_index": 2`, `"jump_index": 1`, 1)
+	_, err = s.service.ImportExpedition([]byte(broken))
+	s.Error(err)
+}
+
+func (s *ExpeditionServiceTestSuite) TestManualCorrections() {
+	jumpTime := time.Date(2025, 12, 20, 10, 0, 0, 0, time.UTC)
+	simulateJump(s.T(), s.tmpDir, Jump{name: "Alpha Centauri", id: 2, distance: &s.distance, fuelUsed: &s.fuelUsed, fuelLevel: &s.fuelLevel}, jumpTime)
+	s.waitForJumps(1)
+	simulateJump(s.T(), s.tmpDir, Jump{name: "Detour", id: 99, distance: &s.distance, fuelUsed: &s.fuelUsed, fuelLevel: &s.fuelLevel}, jumpTime.Add(time.Minute))
+	s.waitForJumps(2)
+	s.Require().Len(s.service.activeExpedition.JumpHistory, 2)
+
+	s.Require().NoError(s.service.MarkHistoryEntry("active", 1, true, "misread"))
+	s.True(s.service.activeExpedition.JumpHistory[1].Erroneous)
+	stats, err := s.service.ExpeditionStats("active", false)
+	s.Require().NoError(err)
+	s.Equal(1, stats.Jumps, "marked entries are not counted")
+
+	s.Require().NoError(s.service.DeleteHistoryEntry("active", 1, ""))
+	s.Require().Len(s.service.activeExpedition.JumpHistory, 1)
+	s.Equal("Alpha Centauri", s.service.currentJump.SystemName)
+	s.Error(s.service.DeleteHistoryEntry("active", 5, ""))
+
+	s.Error(s.service.InsertSkippedJumps("active", 1, ""), "not ahead of the current system")
+	inserted := time.Now()
+	s.Require().NoError(s.service.InsertSkippedJumps("active", 2, "skipped"))
+	history := s.service.activeExpedition.JumpHistory
+	s.Require().Len(history, 2)
+	s.True(history[1].Synthetic)
+	s.False(history[1].Timestamp.Before(inserted), "timestamped when inserted, not after the last jump")
+	s.Equal("Bernard's Star", history[1].SystemName)
+	s.Equal(2, s.service.activeExpedition.CurrentBakedIndex)
+
+	s.Require().NoError(s.service.SetCurrentBakedIndex("active", 1, "back one"))
+	s.Equal(1, s.service.activeExpedition.CurrentBakedIndex)
+
+	expedition, err := models.LoadExpedition("active")
+	s.Require().NoError(err)
+	s.Equal(1, expedition.CurrentBakedIndex)
+	corrections := expedition.Corrections
+	s.Require().Len(corrections, 4)
+	s.Equal(models.CorrectionMarkEntry, corrections[0].Kind)
+	s.Equal("misread", corrections[0].Note)
+	s.Equal(models.CorrectionDeleteEntry, corrections[1].Kind)
+	s.Require().NotNil(corrections[1].Entry)
+	s.Equal("Detour", corrections[1].Entry.SystemName)
+	s.Equal(models.CorrectionInsertSkipped, corrections[2].Kind)
+	s.Equal(1, corrections[2].Inserted)
+	s.Equal(models.CorrectionSetPosition, corrections[3].Kind)
+	s.Equal(2, corrections[3].PrevBakedIndex)
+	s.Equal(1, corrections[3].BakedIndex)
+
+	_, err = s.service.RebuildHistory("active")
+	s.Error(err, "a rebuild would undo the corrections")
+
+	// Setting the position to the end completes the expedition
+	s.Require().NoError(s.service.SetCurrentBakedIndex("active", 3, ""))
+	s.Nil(s.service.activeExpedition)
+}
+
+func (s *ExpeditionServiceTestSuite) TestLapsCompleteAfterMaxLaps() {
+	jumpTime := time.Date(2025, 12, 20, 10, 0, 0, 0, time.UTC)
+	s.loopBackTo(1, jumpTime)
+	s.Require().NoError(s.service.SetMaxLaps("active", 2))
+	s.Error(s.service.SetMaxLaps("active", -1))
+
+	jumps := 0
+	jump := func(name string, id int64, offset time.Duration) {
+		simulateJump(s.T(), s.tmpDir, Jump{name: name, id: id, distance: &s.distance, fuelUsed: &s.fuelUsed, fuelLevel: &s.fuelLevel}, jumpTime.Add(offset))
+		jumps++
+		s.waitForJumps(jumps)
+	}
+
+	jump("Alpha Centauri", 2, 0)
+	s.Equal(4, s.service.PredictETA().RemainingJumps, "the rest of this lap and the next")
+
+	jump("Bernard's Star", 3, time.Minute)
+	jump("Luhman 16", 4, 2*time.Minute)
+	s.Require().NotNil(s.service.activeExpedition, "one lap to go")
+	s.Equal(1, s.service.activeExpedition.CurrentBakedIndex)
+	s.Equal(1, s.service.activeExpedition.CompletedLaps())
+	s.Require().Len(s.service.activeExpedition.Laps, 2)
+	s.Equal(2, s.service.PredictETA().RemainingJumps)
+
+	jump("Bernard's Star", 3, 3*time.Minute)
+	jump("Luhman 16", 4, 4*time.Minute)
+	s.Nil(s.service.activeExpedition, "completed after the second lap")
+
+	expedition, err := models.LoadExpedition("active")
+	s.Require().NoError(err)
+	s.Equal(models.StatusCompleted, expedition.Status)
+	laps := expedition.Laps
+	s.Require().Len(laps, 2)
+	s.Equal(1, laps[0].Number)
+	s.True(laps[0].End.Equal(jumpTime.Add(2 * time.Minute)))
+	s.Require().NotNil(laps[0].Stats)
+	s.Equal(3, laps[0].Stats.Jumps)
+	s.Equal(2, laps[1].Number)
+	s.True(laps[1].Start.Equal(laps[0].End))
+	s.Require().NotNil(laps[1].Stats)
+	s.Equal(2, laps[1].Stats.Jumps)
+	s.Equal(40.0, laps[1].Stats.TotalDistance)
+}
+
+func (s *ExpeditionServiceTestSuite) TestCorrectionToLoopEndWraps() {
+	jumpTime := time.Date(2025, 12, 20, 10, 0, 0, 0, time.UTC)
+	s.loopBackTo(1, jumpTime)
+
+	s.Require().NoError(s.service.SetCurrentBakedIndex("active", 3, "at the end"))
+	s.Equal(1, s.service.activeExpedition.CurrentBakedIndex, "wrapped to the start of the loop")
+	s.Equal(1, s.service.activeExpedition.Corrections[0].BakedIndex)
+
+	s.Require().NoError(s.service.InsertSkippedJumps("active", 3, "skipped"))
+	s.Equal(1, s.service.activeExpedition.CurrentBakedIndex)
+	s.Equal(1, s.service.activeExpedition.CompletedLaps())
+
+	recorded := len(s.service.activeExpedition.JumpHistory)
+	simulateJump(s.T(), s.tmpDir, Jump{name: "Bernard's Star", id: 3, distance: &s.distance, fuelUsed: &s.fuelUsed, fuelLevel: &s.fuelLevel}, time.Now().Add(time.Minute))
+	s.waitForJumps(recorded + 1)
+	s.Equal(2, s.service.activeExpedition.CurrentBakedIndex, "still tracking after the wrap")
+}
+
+func (s *ExpeditionServiceTestSuite) TestFollowsAlternativeBranch() {
+	id := s.createExpedition(
+		[]*models.Route{newRoute("main", 11, 12, 13, 14), newRoute("side", 12, 21, 22, 13)},
+		[2]models.RoutePosition{{RouteID: "main-route", JumpIndex: 1}, {RouteID: "side-route", JumpIndex: 0}},
+		[2]models.RoutePosition{{RouteID: "side-route", JumpIndex: 3}, {RouteID: "main-route", JumpIndex: 2}},
+	)
+
+	expedition, err := models.LoadExpedition(id)
+	s.Require().NoError(err)
+	s.Require().Len(expedition.Links, 2)
+	sideTrip := expedition.Links[0]
+	s.False(sideTrip.Alternative, "the first link from a system is the default")
+	s.Require().NoError(s.service.SetLinkAlternative(id, sideTrip.ID, true))
+
+	s.Error(s.service.CreateLink(id,
+		models.RoutePosition{RouteID: "main-route", JumpIndex: 1},
+		models.RoutePosition{RouteID: "side-route", JumpIndex: 0},
+	), "already linked")
+
+	startSystem := int64(11)
+	s.Require().NoError(s.service.StartExpedition(id, &startSystem, ""))
+	s.Require().Len(s.service.bakedRoute.Jumps, 4, "alternatives are not baked")
+	prevBakedRouteID := s.service.bakedRoute.ID
+
+	recorded := len(s.service.activeExpedition.JumpHistory)
+	jumpTime := time.Now().Add(time.Minute)
+	simulateJump(s.T(), s.tmpDir, Jump{name: "System 12", id: 12, distance: &s.distance, fuelUsed: &s.fuelUsed, fuelLevel: &s.fuelLevel}, jumpTime)
+	s.waitForJumps(recorded + 1)
+	simulateJump(s.T(), s.tmpDir, Jump{name: "System 21", id: 21, distance: &s.distance, fuelUsed: &s.fuelUsed, fuelLevel: &s.fuelLevel}, jumpTime.Add(time.Minute))
+	s.waitForJumps(recorded + 2)
+
+	active := s.service.activeExpedition
+	s.Require().NotNil(active)
+	s.Equal(2, active.CurrentBakedIndex)
+	s.True(active.JumpHistory[len(active.JumpHistory)-1].Expected)
+
+	systems := []int64{}
+	for _, jump := range s.service.bakedRoute.Jumps {
+		systems = append(systems, jump.SystemID)
+	}
+	s.Equal([]int64{11, 12, 21, 22, 13, 14}, systems)
+
+	s.Require().Len(active.Branches, 1)
+	s.Equal(sideTrip.ID, active.Branches[0].LinkID)
+	s.Equal(1, active.Branches[0].BakedIndex)
+
+	saved, err := models.LoadExpedition(id)
+	s.Require().NoError(err)
+	s.Equal(s.service.bakedRoute.ID, *saved.BakedRouteID)
+	_, err = models.LoadRoute(prevBakedRouteID)
+	s.Error(err, "the replaced baked route is deleted")
+}
+
+func (s *ExpeditionServiceTestSuite) TestBranchLoopsBackIntoFlownRoute() {
+	id := s.createExpedition(
+		[]*models.Route{newRoute("main", 11, 12, 13, 14), newRoute("side", 12, 21, 11)},
+		[2]models.RoutePosition{{RouteID: "main-route", JumpIndex: 1}, {RouteID: "side-route", JumpIndex: 0}},
+		[2]models.RoutePosition{{RouteID: "side-route", JumpIndex: 2}, {RouteID: "main-route", JumpIndex: 0}},
+	)
+	expedition, err := models.LoadExpedition(id)
+	s.Require().NoError(err)
+	s.Require().NoError(s.service.SetLinkAlternative(id, expedition.Links[0].ID, true))
+
+	startSystem := int64(11)
+	s.Require().NoError(s.service.StartExpedition(id, &startSystem, ""))
+	s.Require().Nil(s.service.activeExpedition.BakedLoopBackIndex)
+
+	recorded := len(s.service.activeExpedition.JumpHistory)
+	jumpTime := time.Now().Add(time.Minute)
+	simulateJump(s.T(), s.tmpDir, Jump{name: "System 12", id: 12, distance: &s.distance, fuelUsed: &s.fuelUsed, fuelLevel: &s.fuelLevel}, jumpTime)
+	s.waitForJumps(recorded + 1)
+	simulateJump(s.T(), s.tmpDir, Jump{name: "System 21", id: 21, distance: &s.distance, fuelUsed: &s.fuelUsed, fuelLevel: &s.fuelLevel}, jumpTime.Add(time.Minute))
+	s.waitForJumps(recorded + 2)
+
+	systems := []int64{}
+	for _, jump := range s.service.bakedRoute.Jumps {
+		systems = append(systems, jump.SystemID)
+	}
+	s.Equal([]int64{11, 12, 21, 11}, systems, "the branch ends where it meets the flown route")
+	s.Require().NotNil(s.service.activeExpedition.BakedLoopBackIndex)
+	s.Equal(0, *s.service.activeExpedition.BakedLoopBackIndex)
+}
+
+func (s *ExpeditionServiceTestSuite) TestBranchKeepsCompletedLaps() {
+	id := s.createExpedition(
+		[]*models.Route{newRoute("main", 11, 12, 13, 14), newRoute("back", 14, 15, 11), newRoute("side", 12, 21, 13)},
+		[2]models.RoutePosition{{RouteID: "main-route", JumpIndex: 3}, {RouteID: "back-route", JumpIndex: 0}},
+		[2]models.RoutePosition{{RouteID: "back-route", JumpIndex: 2}, {RouteID: "main-route", JumpIndex: 0}},
+		[2]models.RoutePosition{{RouteID: "main-route", JumpIndex: 1}, {RouteID: "side-route", JumpIndex: 0}},
+		[2]models.RoutePosition{{RouteID: "side-route", JumpIndex: 2}, {RouteID: "main-route", JumpIndex: 2}},
+	)
+	expedition, err := models.LoadExpedition(id)
+	s.Require().NoError(err)
+	s.Require().NoError(s.service.SetLinkAlternative(id, expedition.Links[2].ID, true))
+
+	startSystem := int64(11)
+	s.Require().NoError(s.service.StartExpedition(id, &startSystem, ""))
+	s.Require().Len(s.service.bakedRoute.Jumps, 6)
+	s.Require().NotNil(s.service.activeExpedition.BakedLoopBackIndex)
+
+	recorded := len(s.service.activeExpedition.JumpHistory)
+	// Journal timestamps have no fractions of a second
+	jumpTime := time.Now().Truncate(time.Second).Add(time.Minute)
+	fly := func(systems ...int64) {
+		for _, system := range systems {
+			jumpTime = jumpTime.Add(time.Minute)
+			simulateJump(s.T(), s.tmpDir, Jump{name: fmt.Sprintf("System %d", system), id: system, distance: &s.distance, fuelUsed: &s.fuelUsed, fuelLevel: &s.fuelLevel}, jumpTime)
+			recorded++
+			s.waitForJumps(recorded)
+		}
+	}
+
+	fly(12, 13, 14, 15, 11)
+	s.Equal(1, s.service.activeExpedition.CompletedLaps())
+	firstLapEnd := jumpTime
+
+	fly(12, 21)
+	systems := []int64{}
+	for _, jump := range s.service.bakedRoute.Jumps {
+		systems = append(systems, jump.SystemID)
+	}
+	s.Equal([]int64{11, 12, 21, 13, 14, 15, 11}, systems)
+	s.Equal(1, s.service.activeExpedition.CompletedLaps(), "the lap flown before the branch is kept")
+
+	history := s.service.activeExpedition.JumpHistory
+	lapEnd := slices.IndexFunc(history, func(entry models.JumpHistoryEntry) bool { return entry.Timestamp.Equal(firstLapEnd) })
+	s.Require().Greater(lapEnd, -1)
+	s.Require().NotNil(history[lapEnd].BakedIndex)
+	s.Equal(6, *history[lapEnd].BakedIndex, "the end of the lap moved to the end of the new route")
+	s.Require().NotNil(history[lapEnd-1].BakedIndex)
+	s.Equal(5, *history[lapEnd-1].BakedIndex, "System 15 is found on the new route")
+
+	fly(13, 14, 15, 11)
+	s.Equal(2, s.service.activeExpedition.CompletedLaps())
+	laps := s.service.activeExpedition.Laps
+	s.Require().Len(laps, 3)
+	s.True(laps[0].End.Equal(firstLapEnd))
+	s.True(laps[1].End.Equal(jumpTime))
+}
+
+func (s *ExpeditionServiceTestSuite) TestPreflightBlocksInfeasibleRoute() {
+	route := &models.Route{Version: migrations.RouteMigrations.LatestVersion(), ID: "far", Name: "far", Jumps: []models.RouteJump{
+		{SystemName: "System 11", SystemID: 11},
+		{SystemName: "System 12", SystemID: 12, Distance: 400},
+	}}
+
+	id, err := s.service.CreateExpedition()
+	s.Require().NoError(err)
+	s.Require().NoError(s.service.AddRouteToExpedition(id, route))
+
+	checked := 0
+	s.service.SetFeasibilityChecker(func(route *models.Route) (*models.FeasibilityReport, error) {
+		checked++
+		report := &models.FeasibilityReport{Issues: []models.FeasibilityIssue{}}
+		if route.Jumps[1].Distance > 100 {
+			report.AddIssue(models.FeasibilityIssue{
+				Kind:      models.FeasibilityOutOfRange,
+				Severity:  models.FeasibilityError,
+				FromIndex: 0,
+				ToIndex:   1,
+				Message:   "too far",
+			})
+		}
+		return report, nil
+	})
+
+	report, err := s.service.PreflightCheck(id)
+	s.Require().NoError(err)
+	s.True(report.Blocking)
+	s.Len(report.Issues, 1)
+
+	startSystem := int64(11)
+	s.Error(s.service.StartExpedition(id, &startSystem, ""), "blocked by default")
+	s.Require().NotNil(s.service.activeExpedition)
+	s.NotEqual(id, s.service.activeExpedition.ID)
+	expedition, err := models.LoadExpedition(id)
+	s.Require().NoError(err)
+	s.Equal(models.StatusPlanned, expedition.Status)
+	s.Nil(expedition.BakedRouteID)
+
+	s.service.SetPreflightPolicy(models.PreflightWarn)
+	s.Require().NoError(s.service.StartExpedition(id, &startSystem, ""))
+	s.Equal(3, checked)
+	s.Require().NotNil(s.service.activeExpedition)
+	s.Equal(id, s.service.activeExpedition.ID)
+}
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"ed-expedition/journal"
+	"ed-expedition/lib/channels"
+	"time"
+
+	wailsLogger "github.com/wailsapp/wails/v2/pkg/logger"
+)
+
+type HazardKind string
+
+const (
+	HazardOverheatingScooping HazardKind = "overheating_scooping"
+	HazardLowFuel             HazardKind = "low_fuel"
+	HazardInDanger            HazardKind = "in_danger"
+	HazardBeingInterdicted    HazardKind = "being_interdicted"
+)
+
+var AllHazardKind = []struct {
+	Value  HazardKind
+	TSName string
+}{
+	{HazardOverheatingScooping, "OVERHEATING_SCOOPING"},
+	{HazardLowFuel, "LOW_FUEL"},
+	{HazardInDanger, "IN_DANGER"},
+	{HazardBeingInterdicted, "BEING_INTERDICTED"},
+}
+
+// HazardAlert is published when a hazard starts, and again with Active false
+// when it is over.
+type HazardAlert struct {
+	Kind      HazardKind `json:"kind"`
+	Active    bool       `json:"active"`
+	Message   string     `json:"message"`
+	Timestamp time.Time  `json:"timestamp"`
+}
+
+var hazardChecks = []struct {
+	kind    HazardKind
+	message string
+	active  func(status *journal.Status) bool
+}{
+	{
+		HazardOverheatingScooping,
+		"Overheating while scooping, move away from the star",
+		func(status *journal.Status) bool {
+			return status.Has(journal.FlagOverHeating) && status.Has(journal.FlagScoopingFuel)
+		},
+	},
+	{
+		HazardLowFuel,
+		"Fuel is below 25%",
+		func(status *journal.Status) bool { return status.Has(journal.FlagLowFuel) },
+	},
+	{
+		HazardInDanger,
+		"You are in danger",
+		func(status *journal.Status) bool { return status.Has(journal.FlagIsInDanger) },
+	},
+	{
+		HazardBeingInterdicted,
+		"You are being interdicted",
+		func(status *journal.Status) bool { return status.Has(journal.FlagBeingInterdicted) },
+	},
+}
+
+// HazardService watches the status snapshots for hazards to the ship
+type HazardService struct {
+	watcher    *journal.Watcher
+	statusChan chan *journal.Status
+	logger     wailsLogger.Logger
+
+	active map[HazardKind]bool
+
+	Alerts *channels.FanoutChannel[*HazardAlert]
+}
+
+func NewHazardService(logger wailsLogger.Logger) *HazardService {
+	return &HazardService{
+		logger: logger,
+		active: map[HazardKind]bool{},
+
+		Alerts: channels.NewFanoutChannel[*HazardAlert](
+			"HazardAlert", 0, 5*time.Millisecond, logger,
+		),
+	}
+}
+
+func (h *HazardService) SetWatcher(w *journal.Watcher) {
+	h.watcher = w
+}
+
+func (h *HazardService) Start() {
+	if h.watcher == nil || h.statusChan != nil {
+		return
+	}
+
+	h.statusChan = h.watcher.Status.Subscribe()
+	go func() {
+		for status := range h.statusChan {
+			h.handleStatus(status)
+		}
+	}()
+}
+
+func (h *HazardService) Stop() error {
+	if h.watcher == nil {
+		return nil
+	}
+	if h.statusChan != nil {
+		h.watcher.Status.Unsubscribe(h.statusChan)
+		h.statusChan = nil
+	}
+	return nil
+}
+
+func (h *HazardService) handleStatus(status *journal.Status) {
+	// Only ship hazards for now, on foot and in an SRV the flags mean little
+	inShip := status.Has(journal.FlagInMainShip)
+
+	for _, check := range hazardChecks {
+		active := inShip && check.active(status)
+		if active == h.active[check.kind] {
+			continue
+		}
+		h.active[check.kind] = active
+
+		alert := &HazardAlert{Kind: check.kind, Active: active, Timestamp: status.Timestamp}
+		if active {
+			alert.Message = check.message
+		}
+		h.Alerts.Publish(alert)
+	}
+}
@@ -0,0 +1,83 @@
+package services
+
+import (
+	"ed-expedition/journal"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type HazardServiceTestSuite struct {
+	suite.Suite
+	service *HazardService
+	alerts  chan *HazardAlert
+}
+
+func (s *HazardServiceTestSuite) SetupTest() {
+	s.service = NewHazardService(&TestLogger{})
+	s.alerts = s.service.Alerts.Subscribe()
+}
+
+func (s *HazardServiceTestSuite) TearDownTest() {
+	s.service.Alerts.Unsubscribe(s.alerts)
+}
+
+// handle runs handleStatus in the background, Publish waits for the alerts to
+// be read
+func (s *HazardServiceTestSuite) handle(flags journal.Flags) []*HazardAlert {
+	done := make(chan struct{})
+	go func() {
+		s.service.handleStatus(&journal.Status{Timestamp: time.Now(), Flags: &flags})
+		close(done)
+	}()
+
+	alerts := []*HazardAlert{}
+	for {
+		select {
+		case alert := <-s.alerts:
+			alerts = append(alerts, alert)
+		case <-done:
+			return alerts
+		}
+	}
+}
+
+func (s *HazardServiceTestSuite) TestOverheatingOnlyWhileScooping() {
+	s.Empty(s.handle(journal.FlagInMainShip | journal.FlagOverHeating))
+
+	alerts := s.handle(journal.FlagInMainShip | journal.FlagOverHeating | journal.FlagScoopingFuel)
+	s.Require().Len(alerts, 1)
+	s.Equal(HazardOverheatingScooping, alerts[0].Kind)
+	s.True(alerts[0].Active)
+	s.NotEmpty(alerts[0].Message)
+
+	// Unchanged state doesn't repeat the alert
+	s.Empty(s.handle(journal.FlagInMainShip | journal.FlagOverHeating | journal.FlagScoopingFuel))
+
+	alerts = s.handle(journal.FlagInMainShip | journal.FlagScoopingFuel)
+	s.Require().Len(alerts, 1)
+	s.Equal(HazardOverheatingScooping, alerts[0].Kind)
+	s.False(alerts[0].Active)
+}
+
+func (s *HazardServiceTestSuite) TestDangerAndInterdiction() {
+	alerts := s.handle(journal.FlagInMainShip | journal.FlagIsInDanger | journal.FlagBeingInterdicted | journal.FlagLowFuel)
+	kinds := []HazardKind{}
+	for _, alert := range alerts {
+		s.True(alert.Active)
+		kinds = append(kinds, alert.Kind)
+	}
+	s.ElementsMatch([]HazardKind{HazardLowFuel, HazardInDanger, HazardBeingInterdicted}, kinds)
+
+	// Leaving the ship clears ship hazards
+	alerts = s.handle(journal.FlagIsInDanger)
+	s.Len(alerts, 3)
+	for _, alert := range alerts {
+		s.False(alert.Active)
+	}
+}
+
+func TestHazardServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(HazardServiceTestSuite))
+}